@@ -11,7 +11,30 @@ It is generated from these files:
 It has these top-level messages:
 	CacheItem
 	CacheRequest
+	ScoredMember
+	HashField
+	ItemInfo
 	CacheResponse
+	MultiRequest
+	MultiResponse
+	TransactionRequest
+	TransactionResponse
+	MetaRequest
+	MetaResponse
+	WatchRequest
+	WatchEvent
+	PublishRequest
+	PublishResponse
+	SubscribeRequest
+	Message
+	RateLimitRequest
+	RateLimitResponse
+	LockRequest
+	LockResponse
+	QueueRequest
+	QueueResponse
+	StatsRequest
+	StatsResponse
 */
 package cache
 
@@ -38,6 +61,8 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type CacheRequest_Operation int32
 
 const (
+	// does nothing. on a Stream, it's a barrier: it runs once the requests
+	// before it have been answered
 	CacheRequest_NOOP      CacheRequest_Operation = 0
 	CacheRequest_SET       CacheRequest_Operation = 1
 	CacheRequest_CAS       CacheRequest_Operation = 2
@@ -52,6 +77,66 @@ const (
 	CacheRequest_INCREMENT CacheRequest_Operation = 11
 	CacheRequest_DECREMENT CacheRequest_Operation = 12
 	CacheRequest_FLUSHALL  CacheRequest_Operation = 13
+	CacheRequest_INSPECT   CacheRequest_Operation = 14
+	CacheRequest_PEEK      CacheRequest_Operation = 15
+	// hash operations. the hash is item.key, and the fields are in fields
+	CacheRequest_HSET    CacheRequest_Operation = 16
+	CacheRequest_HGET    CacheRequest_Operation = 17
+	CacheRequest_HDEL    CacheRequest_Operation = 18
+	CacheRequest_HGETALL CacheRequest_Operation = 19
+	CacheRequest_HINCRBY CacheRequest_Operation = 20
+	CacheRequest_HLEN    CacheRequest_Operation = 21
+	// list operations. the list is item.key
+	CacheRequest_LPUSH  CacheRequest_Operation = 22
+	CacheRequest_RPUSH  CacheRequest_Operation = 23
+	CacheRequest_LPOP   CacheRequest_Operation = 24
+	CacheRequest_RPOP   CacheRequest_Operation = 25
+	CacheRequest_LRANGE CacheRequest_Operation = 26
+	CacheRequest_LTRIM  CacheRequest_Operation = 27
+	CacheRequest_LLEN   CacheRequest_Operation = 28
+	// set operations. the set is item.key, and members are in values.
+	// SINTER, SUNION and SDIFF operate on item.key followed by keys
+	CacheRequest_SADD      CacheRequest_Operation = 29
+	CacheRequest_SREM      CacheRequest_Operation = 30
+	CacheRequest_SISMEMBER CacheRequest_Operation = 31
+	CacheRequest_SMEMBERS  CacheRequest_Operation = 32
+	CacheRequest_SCARD     CacheRequest_Operation = 33
+	CacheRequest_SINTER    CacheRequest_Operation = 34
+	CacheRequest_SUNION    CacheRequest_Operation = 35
+	CacheRequest_SDIFF     CacheRequest_Operation = 36
+	// sorted set operations. the sorted set is item.key. ZADD and ZINCRBY
+	// take members in scored, ZREM and ZRANK take members in values
+	CacheRequest_ZADD          CacheRequest_Operation = 37
+	CacheRequest_ZINCRBY       CacheRequest_Operation = 38
+	CacheRequest_ZREM          CacheRequest_Operation = 39
+	CacheRequest_ZRANGE        CacheRequest_Operation = 40
+	CacheRequest_ZRANGEBYSCORE CacheRequest_Operation = 41
+	CacheRequest_ZRANK         CacheRequest_Operation = 42
+	CacheRequest_ZCARD         CacheRequest_Operation = 43
+	// HyperLogLog operations. PFADD adds values to item.key, PFCOUNT counts
+	// the union of item.key and keys, and PFMERGE merges keys into item.key
+	CacheRequest_PFADD   CacheRequest_Operation = 44
+	CacheRequest_PFCOUNT CacheRequest_Operation = 45
+	CacheRequest_PFMERGE CacheRequest_Operation = 46
+	// Bloom filter operations. the filter is item.key. BFRESERVE creates it
+	// with capacity and error_rate, BFADD and BFEXISTS take the first of
+	// values, and BFMEXISTS all of them
+	CacheRequest_BFRESERVE CacheRequest_Operation = 47
+	CacheRequest_BFADD     CacheRequest_Operation = 48
+	CacheRequest_BFEXISTS  CacheRequest_Operation = 49
+	CacheRequest_BFMEXISTS CacheRequest_Operation = 50
+	// GETSET sets item and returns the previous value, GETDEL deletes
+	// item.key and returns its value, and SETNX sets item only if it doesn't
+	// exist, otherwise returning the existing value
+	CacheRequest_GETSET CacheRequest_Operation = 51
+	CacheRequest_GETDEL CacheRequest_Operation = 52
+	CacheRequest_SETNX  CacheRequest_Operation = 53
+	// substring operations. GETRANGE returns the bytes of item.key from start
+	// to stop, SETRANGE writes item.value at offset start, zero padding the
+	// value if needed, and STRLEN returns the length of the value
+	CacheRequest_GETRANGE CacheRequest_Operation = 54
+	CacheRequest_SETRANGE CacheRequest_Operation = 55
+	CacheRequest_STRLEN   CacheRequest_Operation = 56
 )
 
 var CacheRequest_Operation_name = map[int32]string{
@@ -69,22 +154,108 @@ var CacheRequest_Operation_name = map[int32]string{
 	11: "INCREMENT",
 	12: "DECREMENT",
 	13: "FLUSHALL",
+	14: "INSPECT",
+	15: "PEEK",
+	16: "HSET",
+	17: "HGET",
+	18: "HDEL",
+	19: "HGETALL",
+	20: "HINCRBY",
+	21: "HLEN",
+	22: "LPUSH",
+	23: "RPUSH",
+	24: "LPOP",
+	25: "RPOP",
+	26: "LRANGE",
+	27: "LTRIM",
+	28: "LLEN",
+	29: "SADD",
+	30: "SREM",
+	31: "SISMEMBER",
+	32: "SMEMBERS",
+	33: "SCARD",
+	34: "SINTER",
+	35: "SUNION",
+	36: "SDIFF",
+	37: "ZADD",
+	38: "ZINCRBY",
+	39: "ZREM",
+	40: "ZRANGE",
+	41: "ZRANGEBYSCORE",
+	42: "ZRANK",
+	43: "ZCARD",
+	44: "PFADD",
+	45: "PFCOUNT",
+	46: "PFMERGE",
+	47: "BFRESERVE",
+	48: "BFADD",
+	49: "BFEXISTS",
+	50: "BFMEXISTS",
+	51: "GETSET",
+	52: "GETDEL",
+	53: "SETNX",
+	54: "GETRANGE",
+	55: "SETRANGE",
+	56: "STRLEN",
 }
 var CacheRequest_Operation_value = map[string]int32{
-	"NOOP":      0,
-	"SET":       1,
-	"CAS":       2,
-	"GET":       3,
-	"GETS":      4,
-	"ADD":       5,
-	"REPLACE":   6,
-	"DELETE":    7,
-	"TOUCH":     8,
-	"APPEND":    9,
-	"PREPEND":   10,
-	"INCREMENT": 11,
-	"DECREMENT": 12,
-	"FLUSHALL":  13,
+	"NOOP":          0,
+	"SET":           1,
+	"CAS":           2,
+	"GET":           3,
+	"GETS":          4,
+	"ADD":           5,
+	"REPLACE":       6,
+	"DELETE":        7,
+	"TOUCH":         8,
+	"APPEND":        9,
+	"PREPEND":       10,
+	"INCREMENT":     11,
+	"DECREMENT":     12,
+	"FLUSHALL":      13,
+	"INSPECT":       14,
+	"PEEK":          15,
+	"HSET":          16,
+	"HGET":          17,
+	"HDEL":          18,
+	"HGETALL":       19,
+	"HINCRBY":       20,
+	"HLEN":          21,
+	"LPUSH":         22,
+	"RPUSH":         23,
+	"LPOP":          24,
+	"RPOP":          25,
+	"LRANGE":        26,
+	"LTRIM":         27,
+	"LLEN":          28,
+	"SADD":          29,
+	"SREM":          30,
+	"SISMEMBER":     31,
+	"SMEMBERS":      32,
+	"SCARD":         33,
+	"SINTER":        34,
+	"SUNION":        35,
+	"SDIFF":         36,
+	"ZADD":          37,
+	"ZINCRBY":       38,
+	"ZREM":          39,
+	"ZRANGE":        40,
+	"ZRANGEBYSCORE": 41,
+	"ZRANK":         42,
+	"ZCARD":         43,
+	"PFADD":         44,
+	"PFCOUNT":       45,
+	"PFMERGE":       46,
+	"BFRESERVE":     47,
+	"BFADD":         48,
+	"BFEXISTS":      49,
+	"BFMEXISTS":     50,
+	"GETSET":        51,
+	"GETDEL":        52,
+	"SETNX":         53,
+	"GETRANGE":      54,
+	"SETRANGE":      55,
+	"STRLEN":        56,
 }
 
 func (x CacheRequest_Operation) String() string {
@@ -92,6 +263,119 @@ func (x CacheRequest_Operation) String() string {
 }
 func (CacheRequest_Operation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 0} }
 
+type MetaRequest_SetMode int32
+
+const (
+	MetaRequest_SET     MetaRequest_SetMode = 0
+	MetaRequest_ADD     MetaRequest_SetMode = 1
+	MetaRequest_REPLACE MetaRequest_SetMode = 2
+	MetaRequest_APPEND  MetaRequest_SetMode = 3
+	MetaRequest_PREPEND MetaRequest_SetMode = 4
+)
+
+var MetaRequest_SetMode_name = map[int32]string{
+	0: "SET",
+	1: "ADD",
+	2: "REPLACE",
+	3: "APPEND",
+	4: "PREPEND",
+}
+var MetaRequest_SetMode_value = map[string]int32{
+	"SET":     0,
+	"ADD":     1,
+	"REPLACE": 2,
+	"APPEND":  3,
+	"PREPEND": 4,
+}
+
+func (x MetaRequest_SetMode) String() string {
+	return proto.EnumName(MetaRequest_SetMode_name, int32(x))
+}
+func (MetaRequest_SetMode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{10, 0} }
+
+type MetaRequest_ArithmeticMode int32
+
+const (
+	MetaRequest_INCREMENT MetaRequest_ArithmeticMode = 0
+	MetaRequest_DECREMENT MetaRequest_ArithmeticMode = 1
+)
+
+var MetaRequest_ArithmeticMode_name = map[int32]string{
+	0: "INCREMENT",
+	1: "DECREMENT",
+}
+var MetaRequest_ArithmeticMode_value = map[string]int32{
+	"INCREMENT": 0,
+	"DECREMENT": 1,
+}
+
+func (x MetaRequest_ArithmeticMode) String() string {
+	return proto.EnumName(MetaRequest_ArithmeticMode_name, int32(x))
+}
+func (MetaRequest_ArithmeticMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{10, 1}
+}
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_SET    WatchEvent_Type = 0
+	WatchEvent_DELETE WatchEvent_Type = 1
+	WatchEvent_TOUCH  WatchEvent_Type = 2
+	WatchEvent_EXPIRE WatchEvent_Type = 3
+	WatchEvent_EVICT  WatchEvent_Type = 4
+	// all keys were flushed; key is empty
+	WatchEvent_FLUSH WatchEvent_Type = 5
+)
+
+var WatchEvent_Type_name = map[int32]string{
+	0: "SET",
+	1: "DELETE",
+	2: "TOUCH",
+	3: "EXPIRE",
+	4: "EVICT",
+	5: "FLUSH",
+}
+var WatchEvent_Type_value = map[string]int32{
+	"SET":    0,
+	"DELETE": 1,
+	"TOUCH":  2,
+	"EXPIRE": 3,
+	"EVICT":  4,
+	"FLUSH":  5,
+}
+
+func (x WatchEvent_Type) String() string {
+	return proto.EnumName(WatchEvent_Type_name, int32(x))
+}
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 0} }
+
+// SlowPolicy is what happens when a subscriber's buffer is full.
+type SubscribeRequest_SlowPolicy int32
+
+const (
+	// drop messages until there's room again
+	SubscribeRequest_DROP SubscribeRequest_SlowPolicy = 0
+	// end the subscription with a RESOURCE_EXHAUSTED error
+	SubscribeRequest_DISCONNECT SubscribeRequest_SlowPolicy = 1
+)
+
+var SubscribeRequest_SlowPolicy_name = map[int32]string{
+	0: "DROP",
+	1: "DISCONNECT",
+}
+var SubscribeRequest_SlowPolicy_value = map[string]int32{
+	"DROP":       0,
+	"DISCONNECT": 1,
+}
+
+func (x SubscribeRequest_SlowPolicy) String() string {
+	return proto.EnumName(SubscribeRequest_SlowPolicy_name, int32(x))
+}
+func (SubscribeRequest_SlowPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{16, 0}
+}
+
 // CacheItem encapsulates any in/out cache values into a single message
 // structure. Some values may not be pertinent in some situations (i.e. you
 // can't set the cas).
@@ -99,7 +383,9 @@ type CacheItem struct {
 	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	Ttl   uint64 `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
-	Cas   uint64 `protobuf:"varint,4,opt,name=cas" json:"cas,omitempty"`
+	// the item's CAS ID. for CAS, DELETE, TOUCH, APPEND and PREPEND, if it's not
+	// 0 the operation only succeeds if the stored item's CAS ID matches
+	Cas uint64 `protobuf:"varint,4,opt,name=cas" json:"cas,omitempty"`
 }
 
 func (m *CacheItem) Reset()                    { *m = CacheItem{} }
@@ -142,6 +428,43 @@ type CacheRequest struct {
 	Prepend   []byte                 `protobuf:"bytes,4,opt,name=prepend,proto3" json:"prepend,omitempty"`
 	Increment uint64                 `protobuf:"varint,5,opt,name=increment" json:"increment,omitempty"`
 	Decrement uint64                 `protobuf:"varint,6,opt,name=decrement" json:"decrement,omitempty"`
+	// fields for hash operations. HGET and HINCRBY use the first field, and
+	// HDEL only needs the field names.
+	Fields []*HashField `protobuf:"bytes,7,rep,name=fields" json:"fields,omitempty"`
+	// amount to add for HINCRBY. a result that wouldn't fit in an int64 fails
+	// with OutOfRange
+	Delta int64 `protobuf:"varint,8,opt,name=delta" json:"delta,omitempty"`
+	// values to push for LPUSH and RPUSH, or members for set operations
+	Values [][]byte `protobuf:"bytes,9,rep,name=values,proto3" json:"values,omitempty"`
+	// inclusive range for LRANGE, LTRIM and GETRANGE. negative indexes count
+	// from the end, so -1 is the last element. start is also the offset for
+	// SETRANGE
+	Start int64 `protobuf:"varint,10,opt,name=start" json:"start,omitempty"`
+	Stop  int64 `protobuf:"varint,11,opt,name=stop" json:"stop,omitempty"`
+	// additional keys for SINTER, SUNION, SDIFF, PFCOUNT and PFMERGE
+	Keys []string `protobuf:"bytes,12,rep,name=keys" json:"keys,omitempty"`
+	// members and scores for ZADD, or the member and amount to add for ZINCRBY
+	Scored []*ScoredMember `protobuf:"bytes,13,rep,name=scored" json:"scored,omitempty"`
+	// inclusive score range for ZRANGEBYSCORE
+	Min float64 `protobuf:"fixed64,14,opt,name=min" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,15,opt,name=max" json:"max,omitempty"`
+	// order ZRANGE, ZRANGEBYSCORE and ZRANK from the highest score
+	Reverse bool `protobuf:"varint,16,opt,name=reverse" json:"reverse,omitempty"`
+	// number of items and false positive rate (between 0 and 1) to size the
+	// filter for with BFRESERVE
+	Capacity  uint64  `protobuf:"varint,17,opt,name=capacity" json:"capacity,omitempty"`
+	ErrorRate float64 `protobuf:"fixed64,18,opt,name=error_rate,json=errorRate" json:"error_rate,omitempty"`
+	// an opaque ID echoed back in the response, to match them up on a Stream
+	RequestId []byte `protobuf:"bytes,19,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// on a Stream, don't send a response if the operation changes the cache
+	// and succeeds, unless the response carries data: LPOP, RPOP, GETDEL,
+	// GETSET, SETNX, INCREMENT, DECREMENT, HINCRBY and ZINCRBY are always
+	// answered. a NOOP is answered only once everything sent before it has
+	// been, so it can be used to wait for quiet operations to finish
+	Quiet bool `protobuf:"varint,20,opt,name=quiet" json:"quiet,omitempty"`
+	// for GET and GETS, if the item's CAS is this, respond with not_modified
+	// rather than the value
+	IfCasDiffers uint64 `protobuf:"varint,21,opt,name=if_cas_differs,json=ifCasDiffers" json:"if_cas_differs,omitempty"`
 }
 
 func (m *CacheRequest) Reset()                    { *m = CacheRequest{} }
@@ -191,230 +514,2304 @@ func (m *CacheRequest) GetDecrement() uint64 {
 	return 0
 }
 
-type CacheResponse struct {
-	Item *CacheItem `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
+func (m *CacheRequest) GetFields() []*HashField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
 }
 
-func (m *CacheResponse) Reset()                    { *m = CacheResponse{} }
-func (m *CacheResponse) String() string            { return proto.CompactTextString(m) }
-func (*CacheResponse) ProtoMessage()               {}
-func (*CacheResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (m *CacheRequest) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
 
-func (m *CacheResponse) GetItem() *CacheItem {
+func (m *CacheRequest) GetValues() [][]byte {
 	if m != nil {
-		return m.Item
+		return m.Values
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*CacheItem)(nil), "cache.CacheItem")
-	proto.RegisterType((*CacheRequest)(nil), "cache.CacheRequest")
-	proto.RegisterType((*CacheResponse)(nil), "cache.CacheResponse")
-	proto.RegisterEnum("cache.CacheRequest_Operation", CacheRequest_Operation_name, CacheRequest_Operation_value)
+func (m *CacheRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Cache service
-
-type CacheClient interface {
-	// streams cache request/response
-	Stream(ctx context.Context, opts ...grpc.CallOption) (Cache_StreamClient, error)
-	// single cache request/response
-	Call(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	// convenience calls for all cache operations. these
-	// just add the Operation and forward on to Call
-	Set(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Cas(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Get(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Gets(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Add(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Replace(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Delete(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Touch(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Append(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Prepend(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Increment(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	Decrement(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
-	FlushAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+func (m *CacheRequest) GetStop() int64 {
+	if m != nil {
+		return m.Stop
+	}
+	return 0
 }
 
-type cacheClient struct {
-	cc *grpc.ClientConn
+func (m *CacheRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
 
-func NewCacheClient(cc *grpc.ClientConn) CacheClient {
-	return &cacheClient{cc}
+func (m *CacheRequest) GetScored() []*ScoredMember {
+	if m != nil {
+		return m.Scored
+	}
+	return nil
 }
 
-func (c *cacheClient) Stream(ctx context.Context, opts ...grpc.CallOption) (Cache_StreamClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Cache_serviceDesc.Streams[0], c.cc, "/cache.Cache/Stream", opts...)
-	if err != nil {
-		return nil, err
+func (m *CacheRequest) GetMin() float64 {
+	if m != nil {
+		return m.Min
 	}
-	x := &cacheStreamClient{stream}
-	return x, nil
+	return 0
 }
 
-type Cache_StreamClient interface {
-	Send(*CacheRequest) error
-	Recv() (*CacheResponse, error)
-	grpc.ClientStream
+func (m *CacheRequest) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
 }
 
-type cacheStreamClient struct {
-	grpc.ClientStream
+func (m *CacheRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
 }
 
-func (x *cacheStreamClient) Send(m *CacheRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *CacheRequest) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
 }
 
-func (x *cacheStreamClient) Recv() (*CacheResponse, error) {
-	m := new(CacheResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *CacheRequest) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
 	}
-	return m, nil
+	return 0
 }
 
-func (c *cacheClient) Call(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Call", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CacheRequest) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *cacheClient) Set(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Set", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CacheRequest) GetQuiet() bool {
+	if m != nil {
+		return m.Quiet
 	}
-	return out, nil
+	return false
 }
 
-func (c *cacheClient) Cas(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Cas", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CacheRequest) GetIfCasDiffers() uint64 {
+	if m != nil {
+		return m.IfCasDiffers
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Get(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Get", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// ScoredMember is a member of a sorted set and its score.
+type ScoredMember struct {
+	Member []byte  `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Score  float64 `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
 }
 
-func (c *cacheClient) Gets(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Gets", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScoredMember) Reset()                    { *m = ScoredMember{} }
+func (m *ScoredMember) String() string            { return proto.CompactTextString(m) }
+func (*ScoredMember) ProtoMessage()               {}
+func (*ScoredMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ScoredMember) GetMember() []byte {
+	if m != nil {
+		return m.Member
 	}
-	return out, nil
+	return nil
 }
 
-func (c *cacheClient) Add(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Add", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScoredMember) GetScore() float64 {
+	if m != nil {
+		return m.Score
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Replace(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Replace", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+// HashField is a field and value in a hash.
+type HashField struct {
+	Field string `protobuf:"bytes,1,opt,name=field" json:"field,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *HashField) Reset()                    { *m = HashField{} }
+func (m *HashField) String() string            { return proto.CompactTextString(m) }
+func (*HashField) ProtoMessage()               {}
+func (*HashField) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *HashField) GetField() string {
+	if m != nil {
+		return m.Field
 	}
-	return out, nil
+	return ""
 }
 
-func (c *cacheClient) Delete(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Delete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *HashField) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return out, nil
+	return nil
 }
 
-func (c *cacheClient) Touch(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Touch", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+// ItemInfo is the metadata about an item returned by INSPECT.
+type ItemInfo struct {
+	// milliseconds since the item was last set or touched
+	AgeMs uint64 `protobuf:"varint,1,opt,name=age_ms,json=ageMs" json:"age_ms,omitempty"`
+	// milliseconds until the item expires, 0 if it doesn't
+	TtlMs uint64 `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs" json:"ttl_ms,omitempty"`
+	// size of the value in bytes
+	Size uint64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	Cas  uint64 `protobuf:"varint,4,opt,name=cas" json:"cas,omitempty"`
+	// milliseconds since the item was last read or written
+	LastAccessMs uint64 `protobuf:"varint,5,opt,name=last_access_ms,json=lastAccessMs" json:"last_access_ms,omitempty"`
+	// true if the item is in the second tier rather than in memory
+	Tiered bool `protobuf:"varint,6,opt,name=tiered" json:"tiered,omitempty"`
+}
+
+func (m *ItemInfo) Reset()                    { *m = ItemInfo{} }
+func (m *ItemInfo) String() string            { return proto.CompactTextString(m) }
+func (*ItemInfo) ProtoMessage()               {}
+func (*ItemInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ItemInfo) GetAgeMs() uint64 {
+	if m != nil {
+		return m.AgeMs
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Append(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Append", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ItemInfo) GetTtlMs() uint64 {
+	if m != nil {
+		return m.TtlMs
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Prepend(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Prepend", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ItemInfo) GetSize() uint64 {
+	if m != nil {
+		return m.Size
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Increment(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Increment", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ItemInfo) GetCas() uint64 {
+	if m != nil {
+		return m.Cas
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) Decrement(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/Decrement", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ItemInfo) GetLastAccessMs() uint64 {
+	if m != nil {
+		return m.LastAccessMs
 	}
-	return out, nil
+	return 0
 }
 
-func (c *cacheClient) FlushAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
-	out := new(CacheResponse)
-	err := grpc.Invoke(ctx, "/cache.Cache/FlushAll", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ItemInfo) GetTiered() bool {
+	if m != nil {
+		return m.Tiered
 	}
-	return out, nil
+	return false
 }
 
-// Server API for Cache service
+type CacheResponse struct {
+	// the item. mutations of plain values return its new CAS, and INCREMENT and
+	// DECREMENT also return the new value
+	Item *CacheItem `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
+	Info *ItemInfo  `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+	// fields returned by HGETALL
+	Fields []*HashField `protobuf:"bytes,3,rep,name=fields" json:"fields,omitempty"`
+	// numeric result: the number of fields added by HSET or deleted by HDEL,
+	// the new value for HINCRBY, the length for HLEN, LPUSH, RPUSH and LLEN,
+	// the number of members added by SADD or removed by SREM, the size for
+	// SCARD, 1 or 0 for SISMEMBER, the number of members added by ZADD or
+	// removed by ZREM, the rank for ZRANK, the size for ZCARD, 1 or 0 for
+	// whether PFADD changed the HyperLogLog, the estimate for PFCOUNT, and 1 or
+	// 0 for whether BFADD added the item or BFEXISTS found it, and 1 or 0 for
+	// whether GETSET or SETNX found an existing item, and the length of the
+	// value for SETRANGE and STRLEN
+	Number int64 `protobuf:"varint,4,opt,name=number" json:"number,omitempty"`
+	// values returned by LRANGE, or members returned by SMEMBERS, SINTER,
+	// SUNION and SDIFF
+	Values [][]byte `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
+	// members and scores returned by ZRANGE and ZRANGEBYSCORE
+	Scored []*ScoredMember `protobuf:"bytes,6,rep,name=scored" json:"scored,omitempty"`
+	// the new score for ZINCRBY
+	Score float64 `protobuf:"fixed64,7,opt,name=score" json:"score,omitempty"`
+	// whether each item may be in the filter for BFMEXISTS
+	Exists []bool `protobuf:"varint,8,rep,packed,name=exists" json:"exists,omitempty"`
+	// for responses that are one of several, like those on a Stream or in a
+	// transaction, the gRPC status code and message the operation would have
+	// failed with on its own. 0 (OK) if it succeeded
+	Code    uint32 `protobuf:"varint,9,opt,name=code" json:"code,omitempty"`
+	Message string `protobuf:"bytes,10,opt,name=message" json:"message,omitempty"`
+	// the request's request_id, for responses that are one of several
+	RequestId []byte `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// true if a GET or GETS with if_cas_differs found the item unchanged. the
+	// item has the key and CAS but no value
+	NotModified bool `protobuf:"varint,12,opt,name=not_modified,json=notModified" json:"not_modified,omitempty"`
+}
+
+func (m *CacheResponse) Reset()                    { *m = CacheResponse{} }
+func (m *CacheResponse) String() string            { return proto.CompactTextString(m) }
+func (*CacheResponse) ProtoMessage()               {}
+func (*CacheResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *CacheResponse) GetItem() *CacheItem {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetInfo() *ItemInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetFields() []*HashField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *CacheResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetScored() []*ScoredMember {
+	if m != nil {
+		return m.Scored
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *CacheResponse) GetExists() []bool {
+	if m != nil {
+		return m.Exists
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CacheResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *CacheResponse) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+func (m *CacheResponse) GetNotModified() bool {
+	if m != nil {
+		return m.NotModified
+	}
+	return false
+}
+
+// MultiRequest is the request for the batch operations. GetMulti and
+// DeleteMulti only need each item's key.
+type MultiRequest struct {
+	Items []*CacheItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *MultiRequest) Reset()                    { *m = MultiRequest{} }
+func (m *MultiRequest) String() string            { return proto.CompactTextString(m) }
+func (*MultiRequest) ProtoMessage()               {}
+func (*MultiRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *MultiRequest) GetItems() []*CacheItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// MultiResponse has a response for each item in a MultiRequest, in the same
+// order. A miss for one item doesn't fail the others; it shows up in that
+// response's code and message.
+type MultiResponse struct {
+	Responses []*CacheResponse `protobuf:"bytes,1,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *MultiResponse) Reset()                    { *m = MultiResponse{} }
+func (m *MultiResponse) String() string            { return proto.CompactTextString(m) }
+func (*MultiResponse) ProtoMessage()               {}
+func (*MultiResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *MultiResponse) GetResponses() []*CacheResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+// TransactionRequest is a list of operations to run atomically, in order,
+// under a single lock. If any of the watched items' CAS doesn't match its
+// current one (0 for an item that doesn't exist), none of the operations are
+// run.
+type TransactionRequest struct {
+	Requests []*CacheRequest `protobuf:"bytes,1,rep,name=requests" json:"requests,omitempty"`
+	// keys and expected CAS values to check before running the operations
+	Watch []*CacheItem `protobuf:"bytes,2,rep,name=watch" json:"watch,omitempty"`
+}
+
+func (m *TransactionRequest) Reset()                    { *m = TransactionRequest{} }
+func (m *TransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*TransactionRequest) ProtoMessage()               {}
+func (*TransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *TransactionRequest) GetRequests() []*CacheRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetWatch() []*CacheItem {
+	if m != nil {
+		return m.Watch
+	}
+	return nil
+}
+
+// TransactionResponse has the result of each operation in a transaction. An
+// operation failing doesn't stop the ones after it, so each result carries
+// its own code and message.
+type TransactionResponse struct {
+	// true if a watched item's CAS didn't match and nothing was run
+	Aborted bool `protobuf:"varint,1,opt,name=aborted" json:"aborted,omitempty"`
+	// the first watched key whose CAS didn't match
+	Conflict  string           `protobuf:"bytes,2,opt,name=conflict" json:"conflict,omitempty"`
+	Responses []*CacheResponse `protobuf:"bytes,3,rep,name=responses" json:"responses,omitempty"`
+}
+
+func (m *TransactionResponse) Reset()                    { *m = TransactionResponse{} }
+func (m *TransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*TransactionResponse) ProtoMessage()               {}
+func (*TransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *TransactionResponse) GetAborted() bool {
+	if m != nil {
+		return m.Aborted
+	}
+	return false
+}
+
+func (m *TransactionResponse) GetConflict() string {
+	if m != nil {
+		return m.Conflict
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetResponses() []*CacheResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+// MetaRequest is the request for the meta commands. Not every flag applies to
+// every command; ones that don't are ignored.
+type MetaRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// the value for MetaSet
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// which item data to return (MetaGet; return_cas also applies to the other
+	// commands)
+	ReturnValue      bool `protobuf:"varint,3,opt,name=return_value,json=returnValue" json:"return_value,omitempty"`
+	ReturnCas        bool `protobuf:"varint,4,opt,name=return_cas,json=returnCas" json:"return_cas,omitempty"`
+	ReturnTtl        bool `protobuf:"varint,5,opt,name=return_ttl,json=returnTtl" json:"return_ttl,omitempty"`
+	ReturnSize       bool `protobuf:"varint,6,opt,name=return_size,json=returnSize" json:"return_size,omitempty"`
+	ReturnHit        bool `protobuf:"varint,7,opt,name=return_hit,json=returnHit" json:"return_hit,omitempty"`
+	ReturnLastAccess bool `protobuf:"varint,8,opt,name=return_last_access,json=returnLastAccess" json:"return_last_access,omitempty"`
+	// a miss isn't an error: the response has miss set instead
+	Quiet bool `protobuf:"varint,9,opt,name=quiet" json:"quiet,omitempty"`
+	// update the item's ttl to ttl (MetaGet, MetaArithmetic)
+	Touch bool `protobuf:"varint,10,opt,name=touch" json:"touch,omitempty"`
+	// ttl in seconds
+	Ttl uint64 `protobuf:"varint,11,opt,name=ttl" json:"ttl,omitempty"`
+	// create the item on a miss with vivify_ttl (MetaGet, MetaArithmetic, and
+	// MetaSet in APPEND/PREPEND mode)
+	Vivify    bool   `protobuf:"varint,12,opt,name=vivify" json:"vivify,omitempty"`
+	VivifyTtl uint64 `protobuf:"varint,13,opt,name=vivify_ttl,json=vivifyTtl" json:"vivify_ttl,omitempty"`
+	// win if the item's remaining ttl in seconds is less than this (MetaGet)
+	RecacheTtl uint64 `protobuf:"varint,14,opt,name=recache_ttl,json=recacheTtl" json:"recache_ttl,omitempty"`
+	// compare cas (MetaSet, MetaDelete, MetaArithmetic)
+	Cas uint64 `protobuf:"varint,15,opt,name=cas" json:"cas,omitempty"`
+	// mark the item stale rather than deleting it (MetaDelete), or store it as
+	// stale if cas is older than the item's (MetaSet)
+	Invalidate bool `protobuf:"varint,16,opt,name=invalidate" json:"invalidate,omitempty"`
+	// don't bump the item in the LRU (MetaGet)
+	NoBump         bool                       `protobuf:"varint,17,opt,name=no_bump,json=noBump" json:"no_bump,omitempty"`
+	SetMode        MetaRequest_SetMode        `protobuf:"varint,18,opt,name=set_mode,json=setMode,enum=cache.MetaRequest_SetMode" json:"set_mode,omitempty"`
+	ArithmeticMode MetaRequest_ArithmeticMode `protobuf:"varint,19,opt,name=arithmetic_mode,json=arithmeticMode,enum=cache.MetaRequest_ArithmeticMode" json:"arithmetic_mode,omitempty"`
+	Delta          uint64                     `protobuf:"varint,20,opt,name=delta" json:"delta,omitempty"`
+	// initial value of a vivified counter (MetaArithmetic)
+	Initial uint64 `protobuf:"varint,21,opt,name=initial" json:"initial,omitempty"`
+}
+
+func (m *MetaRequest) Reset()                    { *m = MetaRequest{} }
+func (m *MetaRequest) String() string            { return proto.CompactTextString(m) }
+func (*MetaRequest) ProtoMessage()               {}
+func (*MetaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *MetaRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MetaRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *MetaRequest) GetReturnValue() bool {
+	if m != nil {
+		return m.ReturnValue
+	}
+	return false
+}
+
+func (m *MetaRequest) GetReturnCas() bool {
+	if m != nil {
+		return m.ReturnCas
+	}
+	return false
+}
+
+func (m *MetaRequest) GetReturnTtl() bool {
+	if m != nil {
+		return m.ReturnTtl
+	}
+	return false
+}
+
+func (m *MetaRequest) GetReturnSize() bool {
+	if m != nil {
+		return m.ReturnSize
+	}
+	return false
+}
+
+func (m *MetaRequest) GetReturnHit() bool {
+	if m != nil {
+		return m.ReturnHit
+	}
+	return false
+}
+
+func (m *MetaRequest) GetReturnLastAccess() bool {
+	if m != nil {
+		return m.ReturnLastAccess
+	}
+	return false
+}
+
+func (m *MetaRequest) GetQuiet() bool {
+	if m != nil {
+		return m.Quiet
+	}
+	return false
+}
+
+func (m *MetaRequest) GetTouch() bool {
+	if m != nil {
+		return m.Touch
+	}
+	return false
+}
+
+func (m *MetaRequest) GetTtl() uint64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *MetaRequest) GetVivify() bool {
+	if m != nil {
+		return m.Vivify
+	}
+	return false
+}
+
+func (m *MetaRequest) GetVivifyTtl() uint64 {
+	if m != nil {
+		return m.VivifyTtl
+	}
+	return 0
+}
+
+func (m *MetaRequest) GetRecacheTtl() uint64 {
+	if m != nil {
+		return m.RecacheTtl
+	}
+	return 0
+}
+
+func (m *MetaRequest) GetCas() uint64 {
+	if m != nil {
+		return m.Cas
+	}
+	return 0
+}
+
+func (m *MetaRequest) GetInvalidate() bool {
+	if m != nil {
+		return m.Invalidate
+	}
+	return false
+}
+
+func (m *MetaRequest) GetNoBump() bool {
+	if m != nil {
+		return m.NoBump
+	}
+	return false
+}
+
+func (m *MetaRequest) GetSetMode() MetaRequest_SetMode {
+	if m != nil {
+		return m.SetMode
+	}
+	return MetaRequest_SET
+}
+
+func (m *MetaRequest) GetArithmeticMode() MetaRequest_ArithmeticMode {
+	if m != nil {
+		return m.ArithmeticMode
+	}
+	return MetaRequest_INCREMENT
+}
+
+func (m *MetaRequest) GetDelta() uint64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *MetaRequest) GetInitial() uint64 {
+	if m != nil {
+		return m.Initial
+	}
+	return 0
+}
+
+// MetaResponse is the response for the meta commands. Only requested item data
+// is filled in.
+type MetaResponse struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Cas   uint64 `protobuf:"varint,3,opt,name=cas" json:"cas,omitempty"`
+	// remaining ttl in seconds, -1 if the item doesn't expire
+	Ttl  int64  `protobuf:"varint,4,opt,name=ttl" json:"ttl,omitempty"`
+	Size uint64 `protobuf:"varint,5,opt,name=size" json:"size,omitempty"`
+	// true if the item had been read before
+	HitBefore bool `protobuf:"varint,6,opt,name=hit_before,json=hitBefore" json:"hit_before,omitempty"`
+	// seconds since the item was last accessed
+	LastAccess uint64 `protobuf:"varint,7,opt,name=last_access,json=lastAccess" json:"last_access,omitempty"`
+	// the client should recache the item
+	Win bool `protobuf:"varint,8,opt,name=win" json:"win,omitempty"`
+	// the item has been invalidated
+	Stale bool `protobuf:"varint,9,opt,name=stale" json:"stale,omitempty"`
+	// another client has already been told to recache the item
+	WinSent bool `protobuf:"varint,10,opt,name=win_sent,json=winSent" json:"win_sent,omitempty"`
+	// the item wasn't found (quiet mode only)
+	Miss bool `protobuf:"varint,11,opt,name=miss" json:"miss,omitempty"`
+	// the counter value (MetaArithmetic)
+	Number uint64 `protobuf:"varint,12,opt,name=number" json:"number,omitempty"`
+}
+
+func (m *MetaResponse) Reset()                    { *m = MetaResponse{} }
+func (m *MetaResponse) String() string            { return proto.CompactTextString(m) }
+func (*MetaResponse) ProtoMessage()               {}
+func (*MetaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *MetaResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MetaResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *MetaResponse) GetCas() uint64 {
+	if m != nil {
+		return m.Cas
+	}
+	return 0
+}
+
+func (m *MetaResponse) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *MetaResponse) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *MetaResponse) GetHitBefore() bool {
+	if m != nil {
+		return m.HitBefore
+	}
+	return false
+}
+
+func (m *MetaResponse) GetLastAccess() uint64 {
+	if m != nil {
+		return m.LastAccess
+	}
+	return 0
+}
+
+func (m *MetaResponse) GetWin() bool {
+	if m != nil {
+		return m.Win
+	}
+	return false
+}
+
+func (m *MetaResponse) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+func (m *MetaResponse) GetWinSent() bool {
+	if m != nil {
+		return m.WinSent
+	}
+	return false
+}
+
+func (m *MetaResponse) GetMiss() bool {
+	if m != nil {
+		return m.Miss
+	}
+	return false
+}
+
+func (m *MetaResponse) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// WatchRequest selects the keys to watch: any key in keys, or starting with
+// any of prefixes. If both are empty, every key is watched.
+type WatchRequest struct {
+	Keys     []string `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	Prefixes []string `protobuf:"bytes,2,rep,name=prefixes" json:"prefixes,omitempty"`
+	// number of events buffered for this watcher before events are dropped, 0
+	// for the server default
+	Buffer uint32 `protobuf:"varint,3,opt,name=buffer" json:"buffer,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *WatchRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetBuffer() uint32 {
+	if m != nil {
+		return m.Buffer
+	}
+	return 0
+}
+
+// WatchEvent is a change to a watched key.
+type WatchEvent struct {
+	Type WatchEvent_Type `protobuf:"varint,1,opt,name=type,enum=cache.WatchEvent_Type" json:"type,omitempty"`
+	Key  string          `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+	// the item's new cas for SET and TOUCH
+	Cas uint64 `protobuf:"varint,3,opt,name=cas" json:"cas,omitempty"`
+	// number of events dropped right before this one because the watcher
+	// wasn't keeping up
+	Dropped uint64 `protobuf:"varint,4,opt,name=dropped" json:"dropped,omitempty"`
+}
+
+func (m *WatchEvent) Reset()                    { *m = WatchEvent{} }
+func (m *WatchEvent) String() string            { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()               {}
+func (*WatchEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *WatchEvent) GetType() WatchEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return WatchEvent_SET
+}
+
+func (m *WatchEvent) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetCas() uint64 {
+	if m != nil {
+		return m.Cas
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+type PublishRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Message []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
+func (m *PublishRequest) String() string            { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()               {}
+func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *PublishRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	// number of subscribers the message was delivered to
+	Receivers uint64 `protobuf:"varint,1,opt,name=receivers" json:"receivers,omitempty"`
+}
+
+func (m *PublishResponse) Reset()                    { *m = PublishResponse{} }
+func (m *PublishResponse) String() string            { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()               {}
+func (*PublishResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *PublishResponse) GetReceivers() uint64 {
+	if m != nil {
+		return m.Receivers
+	}
+	return 0
+}
+
+// SubscribeRequest subscribes to messages published to any of channels, or to
+// any channel matching one of patterns. Patterns are globs: * matches any
+// run of characters, ? any single character and [...] a character class.
+type SubscribeRequest struct {
+	Channels   []string                    `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+	Patterns   []string                    `protobuf:"bytes,2,rep,name=patterns" json:"patterns,omitempty"`
+	SlowPolicy SubscribeRequest_SlowPolicy `protobuf:"varint,3,opt,name=slow_policy,json=slowPolicy,enum=cache.SubscribeRequest_SlowPolicy" json:"slow_policy,omitempty"`
+	// number of messages buffered for this subscriber, 0 for the server default
+	Buffer uint32 `protobuf:"varint,4,opt,name=buffer" json:"buffer,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *SubscribeRequest) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetPatterns() []string {
+	if m != nil {
+		return m.Patterns
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetSlowPolicy() SubscribeRequest_SlowPolicy {
+	if m != nil {
+		return m.SlowPolicy
+	}
+	return SubscribeRequest_DROP
+}
+
+func (m *SubscribeRequest) GetBuffer() uint32 {
+	if m != nil {
+		return m.Buffer
+	}
+	return 0
+}
+
+type Message struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	// the pattern that matched, if the subscription was by pattern
+	Pattern string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+	Message []byte `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// number of messages dropped right before this one (DROP policy)
+	Dropped uint64 `protobuf:"varint,4,opt,name=dropped" json:"dropped,omitempty"`
+}
+
+func (m *Message) Reset()                    { *m = Message{} }
+func (m *Message) String() string            { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()               {}
+func (*Message) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *Message) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Message) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *Message) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *Message) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+// RateLimitRequest checks a request against the rate limit stored at key:
+// rate requests per period, with bursts of up to burst requests beyond that.
+type RateLimitRequest struct {
+	Key  string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Rate int64  `protobuf:"varint,2,opt,name=rate" json:"rate,omitempty"`
+	// the period in milliseconds, 1 second if 0
+	PeriodMs uint64 `protobuf:"varint,3,opt,name=period_ms,json=periodMs" json:"period_ms,omitempty"`
+	Burst    int64  `protobuf:"varint,4,opt,name=burst" json:"burst,omitempty"`
+	// how much of the limit the request uses, 1 if 0
+	Cost int64 `protobuf:"varint,5,opt,name=cost" json:"cost,omitempty"`
+	// report the state of the limit without using any of it. the request is
+	// always allowed and cost is ignored
+	CheckOnly bool `protobuf:"varint,6,opt,name=check_only,json=checkOnly" json:"check_only,omitempty"`
+}
+
+func (m *RateLimitRequest) Reset()                    { *m = RateLimitRequest{} }
+func (m *RateLimitRequest) String() string            { return proto.CompactTextString(m) }
+func (*RateLimitRequest) ProtoMessage()               {}
+func (*RateLimitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *RateLimitRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RateLimitRequest) GetRate() int64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *RateLimitRequest) GetPeriodMs() uint64 {
+	if m != nil {
+		return m.PeriodMs
+	}
+	return 0
+}
+
+func (m *RateLimitRequest) GetBurst() int64 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+func (m *RateLimitRequest) GetCost() int64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+func (m *RateLimitRequest) GetCheckOnly() bool {
+	if m != nil {
+		return m.CheckOnly
+	}
+	return false
+}
+
+type RateLimitResponse struct {
+	Allowed bool `protobuf:"varint,1,opt,name=allowed" json:"allowed,omitempty"`
+	// the number of requests (of cost 1) that would be allowed right now
+	Remaining int64 `protobuf:"varint,2,opt,name=remaining" json:"remaining,omitempty"`
+	// milliseconds to wait before the request would be allowed, 0 if allowed
+	RetryAfterMs uint64 `protobuf:"varint,3,opt,name=retry_after_ms,json=retryAfterMs" json:"retry_after_ms,omitempty"`
+	// milliseconds until the limit is back to its full burst
+	ResetAfterMs uint64 `protobuf:"varint,4,opt,name=reset_after_ms,json=resetAfterMs" json:"reset_after_ms,omitempty"`
+}
+
+func (m *RateLimitResponse) Reset()                    { *m = RateLimitResponse{} }
+func (m *RateLimitResponse) String() string            { return proto.CompactTextString(m) }
+func (*RateLimitResponse) ProtoMessage()               {}
+func (*RateLimitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *RateLimitResponse) GetAllowed() bool {
+	if m != nil {
+		return m.Allowed
+	}
+	return false
+}
+
+func (m *RateLimitResponse) GetRemaining() int64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *RateLimitResponse) GetRetryAfterMs() uint64 {
+	if m != nil {
+		return m.RetryAfterMs
+	}
+	return 0
+}
+
+func (m *RateLimitResponse) GetResetAfterMs() uint64 {
+	if m != nil {
+		return m.ResetAfterMs
+	}
+	return 0
+}
+
+// LockRequest is the request for Lock, Unlock and Renew.
+type LockRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// the owner token returned by Lock, for Unlock and Renew
+	Owner string `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	// the lease for Lock and Renew in milliseconds. it must be greater than 0
+	TtlMs uint64 `protobuf:"varint,3,opt,name=ttl_ms,json=ttlMs" json:"ttl_ms,omitempty"`
+	// for Lock, wait for the lock to be released or expire rather than failing
+	// with AlreadyExists if it's held. it waits until the call's deadline, if any
+	Wait bool `protobuf:"varint,4,opt,name=wait" json:"wait,omitempty"`
+}
+
+func (m *LockRequest) Reset()                    { *m = LockRequest{} }
+func (m *LockRequest) String() string            { return proto.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()               {}
+func (*LockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *LockRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LockRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *LockRequest) GetTtlMs() uint64 {
+	if m != nil {
+		return m.TtlMs
+	}
+	return 0
+}
+
+func (m *LockRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+type LockResponse struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	// the fencing token for the acquisition
+	Fence uint64 `protobuf:"varint,3,opt,name=fence" json:"fence,omitempty"`
+}
+
+func (m *LockResponse) Reset()                    { *m = LockResponse{} }
+func (m *LockResponse) String() string            { return proto.CompactTextString(m) }
+func (*LockResponse) ProtoMessage()               {}
+func (*LockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *LockResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LockResponse) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *LockResponse) GetFence() uint64 {
+	if m != nil {
+		return m.Fence
+	}
+	return 0
+}
+
+// QueueRequest is the request for Enqueue, Receive, Ack and Nack. The queue
+// is key.
+type QueueRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// the message for Enqueue
+	Body []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// milliseconds before an enqueued (or nacked) message can be received
+	DelayMs uint64 `protobuf:"varint,3,opt,name=delay_ms,json=delayMs" json:"delay_ms,omitempty"`
+	// ttl for the whole queue in seconds, for Enqueue
+	Ttl uint64 `protobuf:"varint,4,opt,name=ttl" json:"ttl,omitempty"`
+	// milliseconds a received message is hidden for, 30 seconds if 0
+	VisibilityMs uint64 `protobuf:"varint,5,opt,name=visibility_ms,json=visibilityMs" json:"visibility_ms,omitempty"`
+	// for Receive, if not 0, the number of times a message is delivered before
+	// it's moved to the dead_letter queue (or dropped, if there isn't one). the
+	// dead_letter queue can't be the queue itself
+	MaxReceives uint32 `protobuf:"varint,6,opt,name=max_receives,json=maxReceives" json:"max_receives,omitempty"`
+	DeadLetter  string `protobuf:"bytes,7,opt,name=dead_letter,json=deadLetter" json:"dead_letter,omitempty"`
+	// the receipt from Receive, for Ack and Nack
+	Receipt uint64 `protobuf:"varint,8,opt,name=receipt" json:"receipt,omitempty"`
+}
+
+func (m *QueueRequest) Reset()                    { *m = QueueRequest{} }
+func (m *QueueRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueueRequest) ProtoMessage()               {}
+func (*QueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *QueueRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueueRequest) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *QueueRequest) GetDelayMs() uint64 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
+func (m *QueueRequest) GetTtl() uint64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *QueueRequest) GetVisibilityMs() uint64 {
+	if m != nil {
+		return m.VisibilityMs
+	}
+	return 0
+}
+
+func (m *QueueRequest) GetMaxReceives() uint32 {
+	if m != nil {
+		return m.MaxReceives
+	}
+	return 0
+}
+
+func (m *QueueRequest) GetDeadLetter() string {
+	if m != nil {
+		return m.DeadLetter
+	}
+	return ""
+}
+
+func (m *QueueRequest) GetReceipt() uint64 {
+	if m != nil {
+		return m.Receipt
+	}
+	return 0
+}
+
+type QueueResponse struct {
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Id  uint64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	// the message, for Receive
+	Body    []byte `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Receipt uint64 `protobuf:"varint,4,opt,name=receipt" json:"receipt,omitempty"`
+	// the number of times the message has been received, including this one
+	Receives uint32 `protobuf:"varint,5,opt,name=receives" json:"receives,omitempty"`
+}
+
+func (m *QueueResponse) Reset()                    { *m = QueueResponse{} }
+func (m *QueueResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueueResponse) ProtoMessage()               {}
+func (*QueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *QueueResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueueResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *QueueResponse) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *QueueResponse) GetReceipt() uint64 {
+	if m != nil {
+		return m.Receipt
+	}
+	return 0
+}
+
+func (m *QueueResponse) GetReceives() uint32 {
+	if m != nil {
+		return m.Receives
+	}
+	return 0
+}
+
+type StatsRequest struct {
+}
+
+func (m *StatsRequest) Reset()                    { *m = StatsRequest{} }
+func (m *StatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()               {}
+func (*StatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+type StatsResponse struct {
+	// number of items in memory
+	Items uint64 `protobuf:"varint,1,opt,name=items" json:"items,omitempty"`
+	// number of active Subscribe streams
+	Subscribers uint64 `protobuf:"varint,2,opt,name=subscribers" json:"subscribers,omitempty"`
+	// channels with at least one subscriber
+	Channels uint64 `protobuf:"varint,3,opt,name=channels" json:"channels,omitempty"`
+	// pattern subscriptions across all subscribers
+	Patterns uint64 `protobuf:"varint,4,opt,name=patterns" json:"patterns,omitempty"`
+	// number of active Watch streams
+	Watchers uint64 `protobuf:"varint,5,opt,name=watchers" json:"watchers,omitempty"`
+	// total size of the items in memory
+	Bytes uint64 `protobuf:"varint,6,opt,name=bytes" json:"bytes,omitempty"`
+}
+
+func (m *StatsResponse) Reset()                    { *m = StatsResponse{} }
+func (m *StatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()               {}
+func (*StatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *StatsResponse) GetItems() uint64 {
+	if m != nil {
+		return m.Items
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetSubscribers() uint64 {
+	if m != nil {
+		return m.Subscribers
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetChannels() uint64 {
+	if m != nil {
+		return m.Channels
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetPatterns() uint64 {
+	if m != nil {
+		return m.Patterns
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetWatchers() uint64 {
+	if m != nil {
+		return m.Watchers
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CacheItem)(nil), "cache.CacheItem")
+	proto.RegisterType((*CacheRequest)(nil), "cache.CacheRequest")
+	proto.RegisterType((*ScoredMember)(nil), "cache.ScoredMember")
+	proto.RegisterType((*HashField)(nil), "cache.HashField")
+	proto.RegisterType((*ItemInfo)(nil), "cache.ItemInfo")
+	proto.RegisterType((*CacheResponse)(nil), "cache.CacheResponse")
+	proto.RegisterType((*MultiRequest)(nil), "cache.MultiRequest")
+	proto.RegisterType((*MultiResponse)(nil), "cache.MultiResponse")
+	proto.RegisterType((*TransactionRequest)(nil), "cache.TransactionRequest")
+	proto.RegisterType((*TransactionResponse)(nil), "cache.TransactionResponse")
+	proto.RegisterType((*MetaRequest)(nil), "cache.MetaRequest")
+	proto.RegisterType((*MetaResponse)(nil), "cache.MetaResponse")
+	proto.RegisterType((*WatchRequest)(nil), "cache.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "cache.WatchEvent")
+	proto.RegisterType((*PublishRequest)(nil), "cache.PublishRequest")
+	proto.RegisterType((*PublishResponse)(nil), "cache.PublishResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "cache.SubscribeRequest")
+	proto.RegisterType((*Message)(nil), "cache.Message")
+	proto.RegisterType((*RateLimitRequest)(nil), "cache.RateLimitRequest")
+	proto.RegisterType((*RateLimitResponse)(nil), "cache.RateLimitResponse")
+	proto.RegisterType((*LockRequest)(nil), "cache.LockRequest")
+	proto.RegisterType((*LockResponse)(nil), "cache.LockResponse")
+	proto.RegisterType((*QueueRequest)(nil), "cache.QueueRequest")
+	proto.RegisterType((*QueueResponse)(nil), "cache.QueueResponse")
+	proto.RegisterType((*StatsRequest)(nil), "cache.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "cache.StatsResponse")
+	proto.RegisterEnum("cache.CacheRequest_Operation", CacheRequest_Operation_name, CacheRequest_Operation_value)
+	proto.RegisterEnum("cache.MetaRequest_SetMode", MetaRequest_SetMode_name, MetaRequest_SetMode_value)
+	proto.RegisterEnum("cache.MetaRequest_ArithmeticMode", MetaRequest_ArithmeticMode_name, MetaRequest_ArithmeticMode_value)
+	proto.RegisterEnum("cache.WatchEvent_Type", WatchEvent_Type_name, WatchEvent_Type_value)
+	proto.RegisterEnum("cache.SubscribeRequest_SlowPolicy", SubscribeRequest_SlowPolicy_name, SubscribeRequest_SlowPolicy_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Cache service
+
+type CacheClient interface {
+	// streams cache request/response. an operation failing doesn't end the
+	// stream; its response carries the code and message instead, along with
+	// the request's request_id
+	Stream(ctx context.Context, opts ...grpc.CallOption) (Cache_StreamClient, error)
+	// single cache request/response
+	Call(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	// convenience calls for all cache operations. these
+	// just add the Operation and forward on to Call
+	Set(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Cas(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Get(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Gets(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Add(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Replace(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Delete(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Touch(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Append(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Prepend(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Increment(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Decrement(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	FlushAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Inspect(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	Peek(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HSet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HGet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HDel(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HGetAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HIncrBy(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	HLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	LPush(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	RPush(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	LPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	RPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	LRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	LTrim(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	LLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SRem(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SIsMember(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SMembers(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SCard(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SInter(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SUnion(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SDiff(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZIncrBy(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZRem(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZRangeByScore(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZRank(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	ZCard(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	PFAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	PFCount(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	PFMerge(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	BFReserve(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	BFAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	BFExists(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	BFMExists(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	GetSet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	GetDel(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SetNX(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	GetRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	SetRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	StrLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	// batch versions of Gets, Set and Delete, run under a single lock, with a
+	// result for each item
+	GetMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error)
+	SetMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error)
+	DeleteMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error)
+	// runs a list of operations atomically under a single lock
+	Transaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// blocking pops wait until the list has an element or the call's deadline
+	// passes. these aren't operations, so can't be used with Call or Stream
+	BLPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	BRPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error)
+	// memcached style meta commands, where the behavior of each command is
+	// controlled by the flags in MetaRequest
+	MetaGet(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error)
+	MetaSet(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error)
+	MetaDelete(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error)
+	MetaArithmetic(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error)
+	// streams change and eviction events for the requested keys
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Cache_WatchClient, error)
+	// publish/subscribe messaging, independent of the cached items
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Cache_SubscribeClient, error)
+	// checks a request against a rate limit, using it up if allowed
+	RateLimit(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error)
+	// distributed locks. Lock returns an owner token that Unlock and Renew
+	// require, and a fencing token that increases with every acquisition. the
+	// plain operations, like GET, SET and DELETE, fail with FailedPrecondition
+	// on a lock. a held lock is never evicted to make room for other items: it
+	// only goes away when it's unlocked or its lease runs out
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	Unlock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	Renew(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	// reliable queues. a received message is hidden for a visibility timeout
+	// and delivered again unless it's acked first
+	Enqueue(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error)
+	Receive(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error)
+	Ack(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error)
+	Nack(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error)
+	// server statistics
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+}
+
+type cacheClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCacheClient(cc *grpc.ClientConn) CacheClient {
+	return &cacheClient{cc}
+}
+
+func (c *cacheClient) Stream(ctx context.Context, opts ...grpc.CallOption) (Cache_StreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Cache_serviceDesc.Streams[0], c.cc, "/cache.Cache/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheStreamClient{stream}
+	return x, nil
+}
+
+type Cache_StreamClient interface {
+	Send(*CacheRequest) error
+	Recv() (*CacheResponse, error)
+	grpc.ClientStream
+}
+
+type cacheStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheStreamClient) Send(m *CacheRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *cacheStreamClient) Recv() (*CacheResponse, error) {
+	m := new(CacheResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) Call(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Call", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Set(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Set", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Cas(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Cas", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Get(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Gets(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Gets", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Add(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Replace(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Replace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Delete(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Touch(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Touch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Append(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Append", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Prepend(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Prepend", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Increment(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Increment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Decrement(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Decrement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) FlushAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/FlushAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Inspect(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Inspect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Peek(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Peek", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HSet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HSet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HGet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HGet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HDel(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HDel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HGetAll(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HGetAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HIncrBy(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HIncrBy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) HLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/HLen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) LPush(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/LPush", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) RPush(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/RPush", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) LPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/LPop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) RPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/RPop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) LRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/LRange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) LTrim(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/LTrim", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) LLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/LLen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SAdd", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SRem(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SRem", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SIsMember(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SIsMember", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SMembers(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SMembers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SCard(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SCard", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SInter(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SInter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SUnion(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SUnion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SDiff(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SDiff", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZAdd", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZIncrBy(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZIncrBy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZRem(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZRem", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZRange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZRangeByScore(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZRangeByScore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZRank(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZRank", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) ZCard(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/ZCard", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) PFAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/PFAdd", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) PFCount(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/PFCount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) PFMerge(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/PFMerge", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BFReserve(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BFReserve", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BFAdd(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BFAdd", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BFExists(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BFExists", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BFMExists(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BFMExists", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) GetSet(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/GetSet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) GetDel(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/GetDel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SetNX(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SetNX", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) GetRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/GetRange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SetRange(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SetRange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) StrLen(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/StrLen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) GetMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error) {
+	out := new(MultiResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/GetMulti", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) SetMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error) {
+	out := new(MultiResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/SetMulti", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) DeleteMulti(ctx context.Context, in *MultiRequest, opts ...grpc.CallOption) (*MultiResponse, error) {
+	out := new(MultiResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/DeleteMulti", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Transaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Transaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BLPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BLPop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) BRPop(ctx context.Context, in *CacheRequest, opts ...grpc.CallOption) (*CacheResponse, error) {
+	out := new(CacheResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/BRPop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) MetaGet(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error) {
+	out := new(MetaResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/MetaGet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) MetaSet(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error) {
+	out := new(MetaResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/MetaSet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) MetaDelete(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error) {
+	out := new(MetaResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/MetaDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) MetaArithmetic(ctx context.Context, in *MetaRequest, opts ...grpc.CallOption) (*MetaResponse, error) {
+	out := new(MetaResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/MetaArithmetic", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Cache_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Cache_serviceDesc.Streams[1], c.cc, "/cache.Cache/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cache_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type cacheWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Publish", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Cache_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Cache_serviceDesc.Streams[2], c.cc, "/cache.Cache/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cache_SubscribeClient interface {
+	Recv() (*Message, error)
+	grpc.ClientStream
+}
+
+type cacheSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheSubscribeClient) Recv() (*Message, error) {
+	m := new(Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) RateLimit(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error) {
+	out := new(RateLimitResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/RateLimit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Lock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Unlock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Unlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Renew(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Renew", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Enqueue(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error) {
+	out := new(QueueResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Enqueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Receive(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error) {
+	out := new(QueueResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Receive", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Ack(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error) {
+	out := new(QueueResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Ack", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Nack(ctx context.Context, in *QueueRequest, opts ...grpc.CallOption) (*QueueResponse, error) {
+	out := new(QueueResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Nack", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := grpc.Invoke(ctx, "/cache.Cache/Stats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Cache service
 
 type CacheServer interface {
-	// streams cache request/response
+	// streams cache request/response. an operation failing doesn't end the
+	// stream; its response carries the code and message instead, along with
+	// the request's request_id
 	Stream(Cache_StreamServer) error
 	// single cache request/response
 	Call(context.Context, *CacheRequest) (*CacheResponse, error)
@@ -433,286 +2830,1545 @@ type CacheServer interface {
 	Increment(context.Context, *CacheRequest) (*CacheResponse, error)
 	Decrement(context.Context, *CacheRequest) (*CacheResponse, error)
 	FlushAll(context.Context, *CacheRequest) (*CacheResponse, error)
+	Inspect(context.Context, *CacheRequest) (*CacheResponse, error)
+	Peek(context.Context, *CacheRequest) (*CacheResponse, error)
+	HSet(context.Context, *CacheRequest) (*CacheResponse, error)
+	HGet(context.Context, *CacheRequest) (*CacheResponse, error)
+	HDel(context.Context, *CacheRequest) (*CacheResponse, error)
+	HGetAll(context.Context, *CacheRequest) (*CacheResponse, error)
+	HIncrBy(context.Context, *CacheRequest) (*CacheResponse, error)
+	HLen(context.Context, *CacheRequest) (*CacheResponse, error)
+	LPush(context.Context, *CacheRequest) (*CacheResponse, error)
+	RPush(context.Context, *CacheRequest) (*CacheResponse, error)
+	LPop(context.Context, *CacheRequest) (*CacheResponse, error)
+	RPop(context.Context, *CacheRequest) (*CacheResponse, error)
+	LRange(context.Context, *CacheRequest) (*CacheResponse, error)
+	LTrim(context.Context, *CacheRequest) (*CacheResponse, error)
+	LLen(context.Context, *CacheRequest) (*CacheResponse, error)
+	SAdd(context.Context, *CacheRequest) (*CacheResponse, error)
+	SRem(context.Context, *CacheRequest) (*CacheResponse, error)
+	SIsMember(context.Context, *CacheRequest) (*CacheResponse, error)
+	SMembers(context.Context, *CacheRequest) (*CacheResponse, error)
+	SCard(context.Context, *CacheRequest) (*CacheResponse, error)
+	SInter(context.Context, *CacheRequest) (*CacheResponse, error)
+	SUnion(context.Context, *CacheRequest) (*CacheResponse, error)
+	SDiff(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZAdd(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZIncrBy(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZRem(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZRange(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZRangeByScore(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZRank(context.Context, *CacheRequest) (*CacheResponse, error)
+	ZCard(context.Context, *CacheRequest) (*CacheResponse, error)
+	PFAdd(context.Context, *CacheRequest) (*CacheResponse, error)
+	PFCount(context.Context, *CacheRequest) (*CacheResponse, error)
+	PFMerge(context.Context, *CacheRequest) (*CacheResponse, error)
+	BFReserve(context.Context, *CacheRequest) (*CacheResponse, error)
+	BFAdd(context.Context, *CacheRequest) (*CacheResponse, error)
+	BFExists(context.Context, *CacheRequest) (*CacheResponse, error)
+	BFMExists(context.Context, *CacheRequest) (*CacheResponse, error)
+	GetSet(context.Context, *CacheRequest) (*CacheResponse, error)
+	GetDel(context.Context, *CacheRequest) (*CacheResponse, error)
+	SetNX(context.Context, *CacheRequest) (*CacheResponse, error)
+	GetRange(context.Context, *CacheRequest) (*CacheResponse, error)
+	SetRange(context.Context, *CacheRequest) (*CacheResponse, error)
+	StrLen(context.Context, *CacheRequest) (*CacheResponse, error)
+	// batch versions of Gets, Set and Delete, run under a single lock, with a
+	// result for each item
+	GetMulti(context.Context, *MultiRequest) (*MultiResponse, error)
+	SetMulti(context.Context, *MultiRequest) (*MultiResponse, error)
+	DeleteMulti(context.Context, *MultiRequest) (*MultiResponse, error)
+	// runs a list of operations atomically under a single lock
+	Transaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	// blocking pops wait until the list has an element or the call's deadline
+	// passes. these aren't operations, so can't be used with Call or Stream
+	BLPop(context.Context, *CacheRequest) (*CacheResponse, error)
+	BRPop(context.Context, *CacheRequest) (*CacheResponse, error)
+	// memcached style meta commands, where the behavior of each command is
+	// controlled by the flags in MetaRequest
+	MetaGet(context.Context, *MetaRequest) (*MetaResponse, error)
+	MetaSet(context.Context, *MetaRequest) (*MetaResponse, error)
+	MetaDelete(context.Context, *MetaRequest) (*MetaResponse, error)
+	MetaArithmetic(context.Context, *MetaRequest) (*MetaResponse, error)
+	// streams change and eviction events for the requested keys
+	Watch(*WatchRequest, Cache_WatchServer) error
+	// publish/subscribe messaging, independent of the cached items
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	Subscribe(*SubscribeRequest, Cache_SubscribeServer) error
+	// checks a request against a rate limit, using it up if allowed
+	RateLimit(context.Context, *RateLimitRequest) (*RateLimitResponse, error)
+	// distributed locks. Lock returns an owner token that Unlock and Renew
+	// require, and a fencing token that increases with every acquisition. the
+	// plain operations, like GET, SET and DELETE, fail with FailedPrecondition
+	// on a lock. a held lock is never evicted to make room for other items: it
+	// only goes away when it's unlocked or its lease runs out
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	Unlock(context.Context, *LockRequest) (*LockResponse, error)
+	Renew(context.Context, *LockRequest) (*LockResponse, error)
+	// reliable queues. a received message is hidden for a visibility timeout
+	// and delivered again unless it's acked first
+	Enqueue(context.Context, *QueueRequest) (*QueueResponse, error)
+	Receive(context.Context, *QueueRequest) (*QueueResponse, error)
+	Ack(context.Context, *QueueRequest) (*QueueResponse, error)
+	Nack(context.Context, *QueueRequest) (*QueueResponse, error)
+	// server statistics
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+}
+
+func RegisterCacheServer(s *grpc.Server, srv CacheServer) {
+	s.RegisterService(&_Cache_serviceDesc, srv)
+}
+
+func _Cache_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CacheServer).Stream(&cacheStreamServer{stream})
+}
+
+type Cache_StreamServer interface {
+	Send(*CacheResponse) error
+	Recv() (*CacheRequest, error)
+	grpc.ServerStream
+}
+
+type cacheStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheStreamServer) Send(m *CacheResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *cacheStreamServer) Recv() (*CacheRequest, error) {
+	m := new(CacheRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Cache_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Call(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Set(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Cas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Cas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Cas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Cas(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Get(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Gets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Gets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Gets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Gets(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Add(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Replace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Replace(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Delete(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Touch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Touch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Touch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Touch(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Append_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Append(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Append",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Append(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Prepend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Prepend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Prepend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Prepend(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Increment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Increment(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Decrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Decrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Decrement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Decrement(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_FlushAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).FlushAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/FlushAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).FlushAll(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Inspect(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Peek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Peek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Peek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Peek(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HSet(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HGet(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HDel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HDel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HDel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HDel(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HGetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HGetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HGetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HGetAll(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HIncrBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HIncrBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HIncrBy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HIncrBy(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_HLen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).HLen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/HLen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).HLen(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_LPush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).LPush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/LPush",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).LPush(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_RPush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).RPush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/RPush",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).RPush(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_LPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).LPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/LPop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).LPop(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_RPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).RPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/RPop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).RPop(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_LRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).LRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/LRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).LRange(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_LTrim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).LTrim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/LTrim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).LTrim(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_LLen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).LLen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/LLen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).LLen(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SAdd(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SRem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SRem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SRem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SRem(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SIsMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SIsMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SIsMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SIsMember(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SMembers(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SCard(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SInter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SInter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SInter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SInter(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SUnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SUnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SUnion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SUnion(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SDiff(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZAdd(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZIncrBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZIncrBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZIncrBy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZIncrBy(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZRem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZRem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZRem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZRem(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZRange(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZRangeByScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZRangeByScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZRangeByScore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZRangeByScore(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_ZRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZRank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZRank(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterCacheServer(s *grpc.Server, srv CacheServer) {
-	s.RegisterService(&_Cache_serviceDesc, srv)
+func _Cache_ZCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).ZCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/ZCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).ZCard(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CacheServer).Stream(&cacheStreamServer{stream})
+func _Cache_PFAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).PFAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/PFAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).PFAdd(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Cache_StreamServer interface {
-	Send(*CacheResponse) error
-	Recv() (*CacheRequest, error)
-	grpc.ServerStream
+func _Cache_PFCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).PFCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/PFCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).PFCount(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type cacheStreamServer struct {
-	grpc.ServerStream
+func _Cache_PFMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).PFMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/PFMerge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).PFMerge(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_BFReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).BFReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BFReserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BFReserve(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_BFAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).BFAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BFAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BFAdd(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_BFExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).BFExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BFExists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BFExists(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_BFMExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).BFMExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BFMExists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BFMExists(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_GetSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).GetSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/GetSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).GetSet(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_GetDel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).GetDel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/GetDel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).GetDel(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SetNX_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SetNX(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SetNX",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SetNX(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_GetRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).GetRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/GetRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).GetRange(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SetRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SetRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SetRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SetRange(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_StrLen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).StrLen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/StrLen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).StrLen(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_GetMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).GetMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/GetMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).GetMulti(ctx, req.(*MultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_SetMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).SetMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/SetMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).SetMulti(ctx, req.(*MultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_DeleteMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).DeleteMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/DeleteMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).DeleteMulti(ctx, req.(*MultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Transaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Transaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/Transaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Transaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *cacheStreamServer) Send(m *CacheResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _Cache_BLPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).BLPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BLPop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BLPop(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *cacheStreamServer) Recv() (*CacheRequest, error) {
-	m := new(CacheRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Cache_BRPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(CacheServer).BRPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.Cache/BRPop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).BRPop(ctx, req.(*CacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_MetaGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Call(ctx, in)
+		return srv.(CacheServer).MetaGet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Call",
+		FullMethod: "/cache.Cache/MetaGet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Call(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).MetaGet(ctx, req.(*MetaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_MetaSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Set(ctx, in)
+		return srv.(CacheServer).MetaSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Set",
+		FullMethod: "/cache.Cache/MetaSet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Set(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).MetaSet(ctx, req.(*MetaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Cas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_MetaDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Cas(ctx, in)
+		return srv.(CacheServer).MetaDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Cas",
+		FullMethod: "/cache.Cache/MetaDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Cas(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).MetaDelete(ctx, req.(*MetaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_MetaArithmetic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Get(ctx, in)
+		return srv.(CacheServer).MetaArithmetic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Get",
+		FullMethod: "/cache.Cache/MetaArithmetic",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Get(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).MetaArithmetic(ctx, req.(*MetaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Gets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CacheServer).Watch(m, &cacheWatchServer{stream})
+}
+
+type Cache_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type cacheWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Cache_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Gets(ctx, in)
+		return srv.(CacheServer).Publish(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Gets",
+		FullMethod: "/cache.Cache/Publish",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Gets(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Publish(ctx, req.(*PublishRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CacheServer).Subscribe(m, &cacheSubscribeServer{stream})
+}
+
+type Cache_SubscribeServer interface {
+	Send(*Message) error
+	grpc.ServerStream
+}
+
+type cacheSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheSubscribeServer) Send(m *Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Cache_RateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Add(ctx, in)
+		return srv.(CacheServer).RateLimit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Add",
+		FullMethod: "/cache.Cache/RateLimit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Add(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).RateLimit(ctx, req.(*RateLimitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Replace(ctx, in)
+		return srv.(CacheServer).Lock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Replace",
+		FullMethod: "/cache.Cache/Lock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Replace(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Lock(ctx, req.(*LockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Delete(ctx, in)
+		return srv.(CacheServer).Unlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Delete",
+		FullMethod: "/cache.Cache/Unlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Delete(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Unlock(ctx, req.(*LockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Touch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Touch(ctx, in)
+		return srv.(CacheServer).Renew(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Touch",
+		FullMethod: "/cache.Cache/Renew",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Touch(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Renew(ctx, req.(*LockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Append_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Enqueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Append(ctx, in)
+		return srv.(CacheServer).Enqueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Append",
+		FullMethod: "/cache.Cache/Enqueue",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Append(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Enqueue(ctx, req.(*QueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Prepend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Receive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Prepend(ctx, in)
+		return srv.(CacheServer).Receive(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Prepend",
+		FullMethod: "/cache.Cache/Receive",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Prepend(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Receive(ctx, req.(*QueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Increment(ctx, in)
+		return srv.(CacheServer).Ack(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Increment",
+		FullMethod: "/cache.Cache/Ack",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Increment(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Ack(ctx, req.(*QueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_Decrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Nack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).Decrement(ctx, in)
+		return srv.(CacheServer).Nack(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/Decrement",
+		FullMethod: "/cache.Cache/Nack",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).Decrement(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Nack(ctx, req.(*QueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cache_FlushAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CacheRequest)
+func _Cache_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CacheServer).FlushAll(ctx, in)
+		return srv.(CacheServer).Stats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cache.Cache/FlushAll",
+		FullMethod: "/cache.Cache/Stats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CacheServer).FlushAll(ctx, req.(*CacheRequest))
+		return srv.(CacheServer).Stats(ctx, req.(*StatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -777,6 +4433,258 @@ var _Cache_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FlushAll",
 			Handler:    _Cache_FlushAll_Handler,
 		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Cache_Inspect_Handler,
+		},
+		{
+			MethodName: "Peek",
+			Handler:    _Cache_Peek_Handler,
+		},
+		{
+			MethodName: "HSet",
+			Handler:    _Cache_HSet_Handler,
+		},
+		{
+			MethodName: "HGet",
+			Handler:    _Cache_HGet_Handler,
+		},
+		{
+			MethodName: "HDel",
+			Handler:    _Cache_HDel_Handler,
+		},
+		{
+			MethodName: "HGetAll",
+			Handler:    _Cache_HGetAll_Handler,
+		},
+		{
+			MethodName: "HIncrBy",
+			Handler:    _Cache_HIncrBy_Handler,
+		},
+		{
+			MethodName: "HLen",
+			Handler:    _Cache_HLen_Handler,
+		},
+		{
+			MethodName: "LPush",
+			Handler:    _Cache_LPush_Handler,
+		},
+		{
+			MethodName: "RPush",
+			Handler:    _Cache_RPush_Handler,
+		},
+		{
+			MethodName: "LPop",
+			Handler:    _Cache_LPop_Handler,
+		},
+		{
+			MethodName: "RPop",
+			Handler:    _Cache_RPop_Handler,
+		},
+		{
+			MethodName: "LRange",
+			Handler:    _Cache_LRange_Handler,
+		},
+		{
+			MethodName: "LTrim",
+			Handler:    _Cache_LTrim_Handler,
+		},
+		{
+			MethodName: "LLen",
+			Handler:    _Cache_LLen_Handler,
+		},
+		{
+			MethodName: "SAdd",
+			Handler:    _Cache_SAdd_Handler,
+		},
+		{
+			MethodName: "SRem",
+			Handler:    _Cache_SRem_Handler,
+		},
+		{
+			MethodName: "SIsMember",
+			Handler:    _Cache_SIsMember_Handler,
+		},
+		{
+			MethodName: "SMembers",
+			Handler:    _Cache_SMembers_Handler,
+		},
+		{
+			MethodName: "SCard",
+			Handler:    _Cache_SCard_Handler,
+		},
+		{
+			MethodName: "SInter",
+			Handler:    _Cache_SInter_Handler,
+		},
+		{
+			MethodName: "SUnion",
+			Handler:    _Cache_SUnion_Handler,
+		},
+		{
+			MethodName: "SDiff",
+			Handler:    _Cache_SDiff_Handler,
+		},
+		{
+			MethodName: "ZAdd",
+			Handler:    _Cache_ZAdd_Handler,
+		},
+		{
+			MethodName: "ZIncrBy",
+			Handler:    _Cache_ZIncrBy_Handler,
+		},
+		{
+			MethodName: "ZRem",
+			Handler:    _Cache_ZRem_Handler,
+		},
+		{
+			MethodName: "ZRange",
+			Handler:    _Cache_ZRange_Handler,
+		},
+		{
+			MethodName: "ZRangeByScore",
+			Handler:    _Cache_ZRangeByScore_Handler,
+		},
+		{
+			MethodName: "ZRank",
+			Handler:    _Cache_ZRank_Handler,
+		},
+		{
+			MethodName: "ZCard",
+			Handler:    _Cache_ZCard_Handler,
+		},
+		{
+			MethodName: "PFAdd",
+			Handler:    _Cache_PFAdd_Handler,
+		},
+		{
+			MethodName: "PFCount",
+			Handler:    _Cache_PFCount_Handler,
+		},
+		{
+			MethodName: "PFMerge",
+			Handler:    _Cache_PFMerge_Handler,
+		},
+		{
+			MethodName: "BFReserve",
+			Handler:    _Cache_BFReserve_Handler,
+		},
+		{
+			MethodName: "BFAdd",
+			Handler:    _Cache_BFAdd_Handler,
+		},
+		{
+			MethodName: "BFExists",
+			Handler:    _Cache_BFExists_Handler,
+		},
+		{
+			MethodName: "BFMExists",
+			Handler:    _Cache_BFMExists_Handler,
+		},
+		{
+			MethodName: "GetSet",
+			Handler:    _Cache_GetSet_Handler,
+		},
+		{
+			MethodName: "GetDel",
+			Handler:    _Cache_GetDel_Handler,
+		},
+		{
+			MethodName: "SetNX",
+			Handler:    _Cache_SetNX_Handler,
+		},
+		{
+			MethodName: "GetRange",
+			Handler:    _Cache_GetRange_Handler,
+		},
+		{
+			MethodName: "SetRange",
+			Handler:    _Cache_SetRange_Handler,
+		},
+		{
+			MethodName: "StrLen",
+			Handler:    _Cache_StrLen_Handler,
+		},
+		{
+			MethodName: "GetMulti",
+			Handler:    _Cache_GetMulti_Handler,
+		},
+		{
+			MethodName: "SetMulti",
+			Handler:    _Cache_SetMulti_Handler,
+		},
+		{
+			MethodName: "DeleteMulti",
+			Handler:    _Cache_DeleteMulti_Handler,
+		},
+		{
+			MethodName: "Transaction",
+			Handler:    _Cache_Transaction_Handler,
+		},
+		{
+			MethodName: "BLPop",
+			Handler:    _Cache_BLPop_Handler,
+		},
+		{
+			MethodName: "BRPop",
+			Handler:    _Cache_BRPop_Handler,
+		},
+		{
+			MethodName: "MetaGet",
+			Handler:    _Cache_MetaGet_Handler,
+		},
+		{
+			MethodName: "MetaSet",
+			Handler:    _Cache_MetaSet_Handler,
+		},
+		{
+			MethodName: "MetaDelete",
+			Handler:    _Cache_MetaDelete_Handler,
+		},
+		{
+			MethodName: "MetaArithmetic",
+			Handler:    _Cache_MetaArithmetic_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _Cache_Publish_Handler,
+		},
+		{
+			MethodName: "RateLimit",
+			Handler:    _Cache_RateLimit_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _Cache_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Cache_Unlock_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _Cache_Renew_Handler,
+		},
+		{
+			MethodName: "Enqueue",
+			Handler:    _Cache_Enqueue_Handler,
+		},
+		{
+			MethodName: "Receive",
+			Handler:    _Cache_Receive_Handler,
+		},
+		{
+			MethodName: "Ack",
+			Handler:    _Cache_Ack_Handler,
+		},
+		{
+			MethodName: "Nack",
+			Handler:    _Cache_Nack_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _Cache_Stats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -785,6 +4693,16 @@ var _Cache_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Cache_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Cache_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "cache.proto",
 }
@@ -792,37 +4710,191 @@ var _Cache_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("cache.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 499 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x8a, 0xda, 0x40,
-	0x14, 0xde, 0x6c, 0x7e, 0x34, 0x47, 0x2d, 0xc3, 0x74, 0x29, 0xa1, 0xb4, 0x20, 0xd2, 0x0b, 0xaf,
-	0xa4, 0x68, 0xb7, 0x3f, 0xf4, 0x2a, 0x24, 0xb3, 0xae, 0x60, 0x35, 0x4c, 0xb2, 0xf4, 0x7a, 0x1a,
-	0x0f, 0x28, 0x8d, 0x26, 0x35, 0x63, 0xa1, 0x2f, 0xd1, 0x27, 0xe9, 0x2b, 0xf4, 0xdd, 0xca, 0x4c,
-	0xd4, 0xdd, 0x42, 0x29, 0xcc, 0xde, 0x9d, 0xef, 0xcf, 0xf3, 0x39, 0xc9, 0x04, 0x3a, 0xb9, 0xc8,
-	0xd7, 0x38, 0xaa, 0xf6, 0xa5, 0x2c, 0xa9, 0xab, 0xc1, 0xe0, 0x33, 0xf8, 0x91, 0x1a, 0x66, 0x12,
-	0xb7, 0x94, 0x80, 0xfd, 0x15, 0x7f, 0x04, 0x56, 0xdf, 0x1a, 0xfa, 0x5c, 0x8d, 0xf4, 0x0a, 0xdc,
-	0xef, 0xa2, 0x38, 0x60, 0x70, 0xd9, 0xb7, 0x86, 0x5d, 0xde, 0x00, 0xe5, 0x93, 0xb2, 0x08, 0xec,
-	0xbe, 0x35, 0x74, 0xb8, 0x1a, 0x15, 0x93, 0x8b, 0x3a, 0x70, 0x1a, 0x26, 0x17, 0xf5, 0xe0, 0xa7,
-	0x0d, 0x5d, 0xfd, 0xcb, 0x1c, 0xbf, 0x1d, 0xb0, 0x96, 0xf4, 0x23, 0xf8, 0x65, 0x85, 0x7b, 0x21,
-	0x37, 0xe5, 0x4e, 0xaf, 0x78, 0x32, 0x7e, 0x39, 0x6a, 0x1a, 0x3d, 0xf4, 0x8d, 0x96, 0x27, 0x13,
-	0xbf, 0xf7, 0xd3, 0x57, 0xe0, 0x6c, 0x24, 0x6e, 0x75, 0x8d, 0xce, 0x98, 0x3c, 0xcc, 0xa9, 0xe6,
-	0x5c, 0xab, 0xf4, 0x19, 0x78, 0xa2, 0xaa, 0x70, 0xb7, 0xd2, 0xd5, 0xba, 0xfc, 0x88, 0x68, 0x00,
-	0xad, 0x6a, 0x8f, 0x5a, 0x70, 0xb4, 0x70, 0x82, 0xf4, 0x05, 0xf8, 0x9b, 0x5d, 0xbe, 0xc7, 0x2d,
-	0xee, 0x64, 0xe0, 0xea, 0xf6, 0xf7, 0x84, 0x52, 0x57, 0x78, 0x52, 0xbd, 0x46, 0x3d, 0x13, 0x83,
-	0x5f, 0x16, 0xf8, 0xe7, 0xb2, 0xb4, 0x0d, 0xce, 0x62, 0xb9, 0x4c, 0xc8, 0x05, 0x6d, 0x81, 0x9d,
-	0xb2, 0x8c, 0x58, 0x6a, 0x88, 0xc2, 0x94, 0x5c, 0xaa, 0x61, 0xca, 0x32, 0x62, 0x2b, 0xd3, 0x94,
-	0x65, 0x29, 0x71, 0x14, 0x15, 0xc6, 0x31, 0x71, 0x69, 0x07, 0x5a, 0x9c, 0x25, 0xf3, 0x30, 0x62,
-	0xc4, 0xa3, 0x00, 0x5e, 0xcc, 0xe6, 0x2c, 0x63, 0xa4, 0x45, 0x7d, 0x70, 0xb3, 0xe5, 0x5d, 0x74,
-	0x4b, 0xda, 0x8a, 0x0e, 0x93, 0x84, 0x2d, 0x62, 0xe2, 0x2b, 0x7f, 0xc2, 0x99, 0x06, 0x40, 0x7b,
-	0xe0, 0xcf, 0x16, 0x11, 0x67, 0x9f, 0xd8, 0x22, 0x23, 0x1d, 0x05, 0x63, 0x76, 0x82, 0x5d, 0xda,
-	0x85, 0xf6, 0xcd, 0xfc, 0x2e, 0xbd, 0x0d, 0xe7, 0x73, 0xd2, 0x1b, 0x5c, 0x43, 0xef, 0x78, 0xce,
-	0x75, 0x55, 0xee, 0x6a, 0x3c, 0x9f, 0xa9, 0xf5, 0xbf, 0x33, 0x1d, 0xff, 0xf6, 0xc0, 0xd5, 0x1c,
-	0xfd, 0x00, 0x5e, 0x2a, 0xf7, 0x28, 0xb6, 0xf4, 0xe9, 0x3f, 0x9e, 0xdb, 0xf3, 0xab, 0xbf, 0xc9,
-	0x66, 0xc9, 0xe0, 0x62, 0x68, 0xbd, 0xb6, 0xe8, 0x04, 0x9c, 0x48, 0x14, 0x85, 0x51, 0x90, 0x8e,
-	0xc1, 0x4e, 0x51, 0x1a, 0x67, 0x22, 0x51, 0x1b, 0x67, 0xa6, 0xa6, 0x7b, 0x26, 0xe0, 0x4c, 0x51,
-	0x9a, 0x2f, 0x0a, 0x57, 0x2b, 0xb3, 0xcc, 0x5b, 0x68, 0x71, 0xac, 0x0a, 0x91, 0xa3, 0x59, 0xee,
-	0x1a, 0xbc, 0x18, 0x0b, 0x94, 0x86, 0xb1, 0x37, 0xe0, 0x66, 0xe5, 0x21, 0x5f, 0x1b, 0x2f, 0x0b,
-	0x9b, 0x9b, 0x66, 0xfa, 0xdf, 0x92, 0xe3, 0x3d, 0x34, 0xca, 0xbd, 0x07, 0x7f, 0x76, 0xbe, 0xa3,
-	0xa6, 0xc9, 0x18, 0x1f, 0x95, 0x7c, 0x07, 0xed, 0x9b, 0xe2, 0x50, 0xaf, 0x43, 0xc3, 0xb7, 0xf8,
-	0x8b, 0xa7, 0x3f, 0xb7, 0x93, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x36, 0xe7, 0xb1, 0xde, 0x7d,
-	0x05, 0x00, 0x00,
+	// 2962 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x5a, 0xdf, 0x7a, 0xdb, 0xc6,
+	0xb1, 0x37, 0xc5, 0xff, 0x43, 0x8a, 0x86, 0x21, 0xd9, 0x41, 0x98, 0x38, 0x91, 0x91, 0x9c, 0x1c,
+	0x9d, 0x24, 0x47, 0x49, 0xa4, 0xd8, 0xce, 0x49, 0x72, 0x71, 0x24, 0x12, 0x92, 0x78, 0x42, 0x52,
+	0x3c, 0x0b, 0xda, 0x71, 0x7c, 0xc3, 0x42, 0xe4, 0xca, 0xc2, 0x67, 0x10, 0xa0, 0x81, 0xa5, 0x65,
+	0xb6, 0x17, 0x7d, 0x80, 0x3e, 0x40, 0x6f, 0xda, 0xde, 0xf5, 0xae, 0x37, 0x7d, 0x83, 0xbe, 0x41,
+	0xbf, 0xaf, 0xcf, 0xd0, 0xf7, 0x68, 0xbf, 0x99, 0x5d, 0x80, 0xa0, 0x2c, 0x3b, 0x81, 0xee, 0x66,
+	0x66, 0xe7, 0x87, 0x9d, 0x9d, 0x99, 0x9d, 0x9d, 0x5d, 0x12, 0x6a, 0x63, 0x67, 0x7c, 0xce, 0x77,
+	0x66, 0x61, 0x20, 0x02, 0xbd, 0x48, 0x8c, 0xf9, 0x23, 0x54, 0x5b, 0x48, 0x74, 0x04, 0x9f, 0xea,
+	0x1a, 0xe4, 0x9f, 0xf3, 0x85, 0x91, 0xdb, 0xca, 0x6d, 0x57, 0x19, 0x92, 0xfa, 0x26, 0x14, 0x5f,
+	0x3a, 0xde, 0x9c, 0x1b, 0x6b, 0x5b, 0xb9, 0xed, 0x3a, 0x93, 0x0c, 0xea, 0x09, 0xe1, 0x19, 0xf9,
+	0xad, 0xdc, 0x76, 0x81, 0x21, 0x89, 0x92, 0xb1, 0x13, 0x19, 0x05, 0x29, 0x19, 0x3b, 0x91, 0xf9,
+	0x67, 0x80, 0x3a, 0x7d, 0x99, 0xf1, 0x17, 0x73, 0x1e, 0x09, 0xfd, 0x3b, 0xa8, 0x06, 0x33, 0x1e,
+	0x3a, 0xc2, 0x0d, 0x7c, 0x9a, 0xa2, 0xb1, 0x7b, 0x77, 0x47, 0x5a, 0x94, 0xd6, 0xdb, 0x39, 0x89,
+	0x95, 0xd8, 0x52, 0x5f, 0xff, 0x18, 0x0a, 0xae, 0xe0, 0x53, 0x32, 0xa3, 0xb6, 0xab, 0xa5, 0x71,
+	0x68, 0x39, 0xa3, 0x51, 0xfd, 0x0e, 0x94, 0x9c, 0xd9, 0x8c, 0xfb, 0x13, 0x32, 0xad, 0xce, 0x14,
+	0xa7, 0x1b, 0x50, 0x9e, 0x85, 0x9c, 0x06, 0x0a, 0x34, 0x10, 0xb3, 0xfa, 0xfb, 0x50, 0x75, 0xfd,
+	0x71, 0xc8, 0xa7, 0xdc, 0x17, 0x46, 0x91, 0xac, 0x5f, 0x0a, 0x70, 0x74, 0xc2, 0xe3, 0xd1, 0x92,
+	0x1c, 0x4d, 0x04, 0xfa, 0x36, 0x94, 0xce, 0x5c, 0xee, 0x4d, 0x22, 0xa3, 0xbc, 0x95, 0x4f, 0x59,
+	0x75, 0xec, 0x44, 0xe7, 0x87, 0x38, 0xc0, 0xd4, 0x38, 0x7a, 0x71, 0xc2, 0x3d, 0xe1, 0x18, 0x95,
+	0xad, 0xdc, 0x76, 0x9e, 0x49, 0x06, 0xad, 0x25, 0x77, 0x46, 0x46, 0x75, 0x2b, 0x8f, 0xd6, 0x4a,
+	0x0e, 0xb5, 0x23, 0xe1, 0x84, 0xc2, 0x00, 0xa9, 0x4d, 0x8c, 0xae, 0x43, 0x21, 0x12, 0xc1, 0xcc,
+	0xa8, 0x91, 0x90, 0x68, 0x94, 0x3d, 0xe7, 0x8b, 0xc8, 0xa8, 0x6f, 0xe5, 0xb7, 0xab, 0x8c, 0x68,
+	0xfd, 0x33, 0x28, 0x45, 0xe3, 0x20, 0xe4, 0x13, 0x63, 0x9d, 0xac, 0xda, 0x50, 0x56, 0xd9, 0x24,
+	0xec, 0xf1, 0xe9, 0x29, 0x0f, 0x99, 0x52, 0xc1, 0xb0, 0x4d, 0x5d, 0xdf, 0x68, 0x6c, 0xe5, 0xb6,
+	0x73, 0x0c, 0x49, 0x92, 0x38, 0xaf, 0x8c, 0x9b, 0x4a, 0xe2, 0xbc, 0x42, 0xe7, 0x85, 0xfc, 0x25,
+	0x0f, 0x23, 0x6e, 0x68, 0x5b, 0xb9, 0xed, 0x0a, 0x8b, 0x59, 0xbd, 0x09, 0x95, 0xb1, 0x33, 0x73,
+	0xc6, 0xae, 0x58, 0x18, 0xb7, 0xc8, 0x3b, 0x09, 0xaf, 0xdf, 0x05, 0xe0, 0x61, 0x18, 0x84, 0xa3,
+	0xd0, 0x11, 0xdc, 0xd0, 0xe9, 0x73, 0x55, 0x92, 0x30, 0x47, 0x70, 0x1c, 0x0e, 0x65, 0xbc, 0x47,
+	0xee, 0xc4, 0xd8, 0xa0, 0xa0, 0x54, 0x95, 0xa4, 0x33, 0x41, 0x17, 0xbc, 0x98, 0xbb, 0x5c, 0x18,
+	0x9b, 0x34, 0xa3, 0x64, 0xf4, 0x8f, 0xa1, 0xe1, 0x9e, 0x8d, 0xc6, 0x4e, 0x34, 0x9a, 0xb8, 0x67,
+	0x67, 0x3c, 0x8c, 0x8c, 0xdb, 0x34, 0x6b, 0xdd, 0x3d, 0x6b, 0x39, 0x51, 0x5b, 0xca, 0xcc, 0xbf,
+	0x14, 0xa1, 0x9a, 0xe4, 0x90, 0x5e, 0x81, 0x42, 0xff, 0xe4, 0x64, 0xa0, 0xdd, 0xd0, 0xcb, 0x90,
+	0xb7, 0xad, 0xa1, 0x96, 0x43, 0xa2, 0xb5, 0x6f, 0x6b, 0x6b, 0x48, 0x1c, 0x59, 0x43, 0x2d, 0x8f,
+	0x4a, 0x47, 0xd6, 0xd0, 0xd6, 0x0a, 0x28, 0xda, 0x6f, 0xb7, 0xb5, 0xa2, 0x5e, 0x83, 0x32, 0xb3,
+	0x06, 0xdd, 0xfd, 0x96, 0xa5, 0x95, 0x74, 0x80, 0x52, 0xdb, 0xea, 0x5a, 0x43, 0x4b, 0x2b, 0xeb,
+	0x55, 0x28, 0x0e, 0x4f, 0x1e, 0xb5, 0x8e, 0xb5, 0x0a, 0x8a, 0xf7, 0x07, 0x03, 0xab, 0xdf, 0xd6,
+	0xaa, 0xa8, 0x3f, 0x60, 0x16, 0x31, 0xa0, 0xaf, 0x43, 0xb5, 0xd3, 0x6f, 0x31, 0xab, 0x67, 0xf5,
+	0x87, 0x5a, 0x0d, 0xd9, 0xb6, 0x15, 0xb3, 0x75, 0xbd, 0x0e, 0x95, 0xc3, 0xee, 0x23, 0xfb, 0x78,
+	0xbf, 0xdb, 0xd5, 0xd6, 0x11, 0xd8, 0xe9, 0xdb, 0x03, 0xab, 0x35, 0xd4, 0x1a, 0x68, 0xc8, 0xc0,
+	0xb2, 0x7e, 0xd0, 0x6e, 0x22, 0x75, 0x8c, 0xe6, 0x6a, 0x44, 0xa1, 0x99, 0xb7, 0x88, 0x6a, 0x5b,
+	0x5d, 0x4d, 0x47, 0x10, 0xca, 0xf0, 0x0b, 0x1b, 0xc4, 0xe0, 0x74, 0x07, 0x3f, 0x69, 0x9b, 0xa4,
+	0xd3, 0xb5, 0xfa, 0xda, 0x6d, 0x34, 0xb4, 0x3b, 0x78, 0x64, 0x1f, 0x6b, 0x77, 0x90, 0x64, 0x44,
+	0xbe, 0x83, 0xe3, 0xdd, 0xc1, 0xc9, 0x40, 0x33, 0x90, 0x62, 0x48, 0xbd, 0x8b, 0xeb, 0xe8, 0xb2,
+	0xfd, 0xfe, 0x91, 0xa5, 0x35, 0x09, 0x35, 0x64, 0x9d, 0x9e, 0xf6, 0x1e, 0xa9, 0xe2, 0xa7, 0xde,
+	0x47, 0xca, 0x46, 0xb7, 0xdc, 0x25, 0x8a, 0x59, 0x3d, 0xed, 0x03, 0x5c, 0x94, 0xdd, 0xb1, 0x7b,
+	0x56, 0xef, 0xc0, 0x62, 0xda, 0x87, 0xb8, 0x28, 0xc5, 0xd8, 0xda, 0x16, 0x7e, 0xc5, 0x6e, 0xed,
+	0xb3, 0xb6, 0x76, 0x0f, 0x3f, 0x6e, 0x77, 0xfa, 0x43, 0x8b, 0x69, 0x26, 0xd1, 0x8f, 0xfa, 0x9d,
+	0x93, 0xbe, 0xf6, 0x11, 0xa9, 0xb4, 0x3b, 0x87, 0x87, 0xda, 0xc7, 0xf8, 0xd1, 0xa7, 0xf8, 0xf9,
+	0xff, 0xc0, 0xa5, 0x3c, 0x55, 0x4b, 0xf9, 0x84, 0xc4, 0x38, 0xd7, 0x7f, 0x22, 0xee, 0xa9, 0x34,
+	0x70, 0x5b, 0xbf, 0x05, 0xeb, 0x92, 0x3e, 0xf8, 0xc9, 0x6e, 0x9d, 0x30, 0x4b, 0xfb, 0x2f, 0xfc,
+	0x14, 0x8a, 0x7e, 0xd0, 0x3e, 0x25, 0x92, 0x26, 0xfe, 0x0c, 0xc9, 0xc1, 0x21, 0x7e, 0xf6, 0x73,
+	0x0a, 0xce, 0x61, 0xeb, 0xe4, 0x51, 0x7f, 0xa8, 0xfd, 0xb7, 0x64, 0x7a, 0x16, 0x3b, 0xb2, 0xb4,
+	0x1d, 0x5c, 0xc5, 0xc1, 0x21, 0xb3, 0x6c, 0x8b, 0x3d, 0xb6, 0xb4, 0x2f, 0x10, 0x73, 0x40, 0x98,
+	0x2f, 0x71, 0x41, 0x07, 0x87, 0xd6, 0x93, 0x8e, 0x3d, 0xb4, 0xb5, 0xaf, 0xa4, 0x5e, 0x4f, 0xb1,
+	0xbb, 0x68, 0x10, 0x26, 0x8c, 0x35, 0xd4, 0xf6, 0x14, 0x8d, 0x71, 0xf9, 0x9a, 0x16, 0x65, 0x0d,
+	0xfb, 0x4f, 0xb4, 0xfb, 0x88, 0x3f, 0xb2, 0x86, 0xd2, 0xea, 0x07, 0xe4, 0x9e, 0x98, 0x7b, 0x48,
+	0x7e, 0x18, 0x32, 0xf4, 0xed, 0x37, 0xe6, 0xf7, 0x50, 0x4f, 0x6f, 0x4d, 0xac, 0x0a, 0x53, 0xa2,
+	0xa8, 0x46, 0xd6, 0x99, 0xe2, 0xa8, 0x2a, 0xa0, 0x1e, 0x95, 0xc0, 0x1c, 0x93, 0x8c, 0xf9, 0x10,
+	0xaa, 0x49, 0xb9, 0x41, 0x15, 0x2a, 0x38, 0xaa, 0x80, 0x17, 0xcf, 0x62, 0xe9, 0xeb, 0x25, 0xdc,
+	0xfc, 0x43, 0x0e, 0x2a, 0x58, 0x39, 0x3b, 0xfe, 0x59, 0xa0, 0xdf, 0x86, 0x92, 0xf3, 0x8c, 0x8f,
+	0xa6, 0x11, 0x21, 0x0b, 0xac, 0xe8, 0x3c, 0xe3, 0xbd, 0x08, 0xc5, 0x42, 0x78, 0x28, 0x5e, 0x93,
+	0x62, 0x21, 0xbc, 0x5e, 0x44, 0x95, 0xc8, 0xfd, 0x35, 0x57, 0xe5, 0x9f, 0xe8, 0xd7, 0xeb, 0x3f,
+	0x6e, 0x56, 0xcf, 0x89, 0xc4, 0xc8, 0x19, 0x8f, 0x79, 0x14, 0xe1, 0x47, 0x64, 0x79, 0xad, 0xa3,
+	0x74, 0x9f, 0x84, 0xbd, 0x08, 0x57, 0x2b, 0x5c, 0x8e, 0xd5, 0xaa, 0x44, 0x3b, 0x5d, 0x71, 0xe6,
+	0xbf, 0xd6, 0x60, 0x5d, 0x9d, 0x0a, 0xd1, 0x2c, 0xf0, 0x23, 0x9e, 0x9c, 0x00, 0xb9, 0xb7, 0x9e,
+	0x00, 0x1f, 0x41, 0xc1, 0xf5, 0xcf, 0x02, 0x75, 0x4e, 0xdc, 0x54, 0x5a, 0xf1, 0x42, 0x19, 0x0d,
+	0xa6, 0x0a, 0x77, 0xfe, 0x67, 0x0a, 0xf7, 0x1d, 0x28, 0xf9, 0x73, 0x0a, 0x46, 0x81, 0xca, 0xae,
+	0xe2, 0x52, 0xa5, 0xbb, 0xb8, 0x52, 0xba, 0x97, 0xc5, 0xb7, 0xf4, 0xf3, 0xc5, 0x37, 0x89, 0x68,
+	0x39, 0x15, 0x51, 0xfc, 0x34, 0x7f, 0xe5, 0x46, 0x22, 0x32, 0x2a, 0x5b, 0x79, 0xf4, 0x88, 0xe4,
+	0xd0, 0xeb, 0xe3, 0x60, 0xc2, 0x8d, 0xea, 0x56, 0x6e, 0x7b, 0x9d, 0x11, 0x8d, 0xa5, 0x79, 0xca,
+	0xa3, 0xc8, 0x79, 0xc6, 0xe9, 0xac, 0xa8, 0xb2, 0x98, 0xbd, 0x54, 0x5f, 0x6b, 0x97, 0xeb, 0xeb,
+	0x3d, 0xa8, 0xfb, 0x81, 0x18, 0x4d, 0x83, 0x89, 0x7b, 0xe6, 0xf2, 0x89, 0x51, 0x27, 0xe7, 0xd7,
+	0xfc, 0x40, 0xf4, 0x94, 0xc8, 0x7c, 0x00, 0xf5, 0xde, 0xdc, 0x13, 0x6e, 0x7c, 0x7c, 0x7f, 0x02,
+	0x45, 0xf4, 0x30, 0xa6, 0x48, 0xfe, 0xca, 0x00, 0xc8, 0x61, 0xb3, 0x05, 0xeb, 0x0a, 0xa7, 0x02,
+	0xb7, 0x0b, 0xd5, 0x50, 0xd1, 0x31, 0x78, 0x73, 0xf5, 0xdc, 0x97, 0x83, 0x6c, 0xa9, 0x66, 0x4e,
+	0x41, 0x1f, 0x86, 0x8e, 0x1f, 0x39, 0x63, 0x6a, 0x04, 0x94, 0x09, 0x5f, 0x40, 0x45, 0x2d, 0x21,
+	0xfe, 0xd0, 0xc6, 0x15, 0x0d, 0x04, 0x4b, 0x94, 0xd0, 0xe6, 0x0b, 0x47, 0x8c, 0xcf, 0x8d, 0xb5,
+	0x37, 0xd9, 0x4c, 0xc3, 0xe6, 0x6f, 0x61, 0x63, 0x65, 0x3a, 0x65, 0xb9, 0x01, 0x65, 0xe7, 0x34,
+	0x08, 0x05, 0x97, 0x3b, 0xaa, 0xc2, 0x62, 0x96, 0x4e, 0xbe, 0xc0, 0x3f, 0xf3, 0xdc, 0xb1, 0xa0,
+	0x54, 0xab, 0xb2, 0x84, 0x5f, 0x5d, 0x6f, 0xfe, 0x97, 0xad, 0xf7, 0x77, 0x25, 0xa8, 0xf5, 0xb8,
+	0x70, 0xe2, 0x95, 0xfe, 0xd2, 0x46, 0xec, 0x1e, 0xd4, 0x43, 0x2e, 0xe6, 0xa1, 0x3f, 0x92, 0x83,
+	0x79, 0x19, 0x47, 0x29, 0x7b, 0x4c, 0x2a, 0x94, 0x09, 0xa4, 0x12, 0x6f, 0xd0, 0x0a, 0xab, 0x4a,
+	0x49, 0xcb, 0x89, 0x52, 0xc3, 0xd8, 0xd1, 0x15, 0xd3, 0xc3, 0x43, 0xe1, 0xe9, 0x1f, 0x82, 0xfa,
+	0xd8, 0x88, 0xb6, 0xbc, 0xdc, 0xa4, 0x0a, 0x61, 0xe3, 0xc6, 0x5f, 0xe2, 0xcf, 0x5d, 0x61, 0x94,
+	0xd3, 0xf8, 0x63, 0x57, 0xe8, 0x9f, 0x83, 0xae, 0x86, 0x53, 0xc5, 0x80, 0xda, 0xa0, 0x0a, 0xd3,
+	0xe4, 0x48, 0x37, 0xa9, 0x07, 0xcb, 0x63, 0xbf, 0x9a, 0x3e, 0xf6, 0x37, 0xa1, 0x28, 0x82, 0xf9,
+	0xf8, 0x9c, 0x72, 0xbc, 0xc2, 0x24, 0x13, 0xf7, 0xa0, 0xb5, 0x65, 0x0f, 0x8a, 0x9b, 0xd2, 0x7d,
+	0xe9, 0x9e, 0x2d, 0x54, 0x3a, 0x2b, 0x0e, 0x4d, 0x94, 0x14, 0x2d, 0x71, 0x5d, 0xb6, 0x71, 0x52,
+	0x92, 0x2c, 0x91, 0xe2, 0x43, 0xe3, 0x0d, 0x1a, 0x07, 0x25, 0x1a, 0x2e, 0x7b, 0xdb, 0x9b, 0xcb,
+	0xda, 0xf6, 0x01, 0x80, 0xeb, 0xbf, 0x74, 0x3c, 0x77, 0xe2, 0x88, 0xb8, 0x2b, 0x4a, 0x49, 0xf4,
+	0x77, 0xa0, 0xec, 0x07, 0xa3, 0xd3, 0xf9, 0x74, 0x46, 0x7d, 0x51, 0x85, 0x95, 0xfc, 0xe0, 0x60,
+	0x3e, 0x9d, 0xe9, 0xf7, 0xa1, 0x12, 0x71, 0xda, 0x77, 0xb2, 0x27, 0x6a, 0xec, 0x36, 0x55, 0x6a,
+	0xa4, 0xa2, 0xbf, 0x63, 0x73, 0xdc, 0x86, 0x9c, 0x95, 0x23, 0x49, 0xe8, 0xff, 0x07, 0x37, 0x9d,
+	0xd0, 0x15, 0xe7, 0x53, 0x2e, 0xdc, 0xb1, 0x44, 0x6f, 0x10, 0xfa, 0xde, 0x15, 0xe8, 0xfd, 0x44,
+	0x93, 0x3e, 0xd2, 0x70, 0x56, 0xf8, 0x65, 0x2f, 0xba, 0x29, 0x6b, 0x3a, 0x31, 0x98, 0xea, 0xae,
+	0xef, 0x0a, 0xd7, 0xf1, 0x54, 0x4f, 0x15, 0xb3, 0xe6, 0x3e, 0x94, 0x95, 0x3d, 0x71, 0x07, 0x75,
+	0x23, 0xee, 0x92, 0x72, 0xe9, 0x2e, 0x69, 0x2d, 0xd5, 0x0e, 0xe5, 0xd3, 0xed, 0x50, 0xc1, 0xdc,
+	0x81, 0xc6, 0xaa, 0x51, 0xab, 0x0d, 0xd2, 0x8d, 0xd5, 0x06, 0x29, 0x67, 0xfe, 0x71, 0x0d, 0xea,
+	0x72, 0x45, 0x6a, 0x23, 0x66, 0xb8, 0x97, 0x60, 0xa4, 0xf2, 0xcb, 0x48, 0xa9, 0x2c, 0x91, 0xd5,
+	0x1b, 0xc9, 0xe4, 0xf4, 0x2a, 0xa6, 0x4e, 0xaf, 0xbb, 0x00, 0xe7, 0xae, 0x18, 0x9d, 0xf2, 0xb3,
+	0x20, 0x8c, 0x93, 0xbc, 0x7a, 0xee, 0x8a, 0x03, 0x12, 0x60, 0x86, 0xa4, 0xb3, 0xb7, 0x2c, 0x33,
+	0x64, 0x79, 0x8e, 0xe1, 0x2c, 0x17, 0xae, 0xaf, 0xd2, 0x1a, 0x49, 0xd5, 0xc3, 0x7b, 0x3c, 0xce,
+	0x64, 0x62, 0xf4, 0x77, 0xa1, 0x72, 0xe1, 0xfa, 0xa3, 0x08, 0xaf, 0x13, 0x32, 0x99, 0xcb, 0x17,
+	0xae, 0x6f, 0xe3, 0x65, 0x42, 0x87, 0xc2, 0xd4, 0x8d, 0x22, 0xca, 0xe7, 0x0a, 0x23, 0x3a, 0x75,
+	0xfa, 0xd4, 0x69, 0x4a, 0xc5, 0x99, 0x8f, 0xa1, 0xfe, 0x23, 0xd6, 0xad, 0xb8, 0x5a, 0xc4, 0xd7,
+	0x80, 0x5c, 0xea, 0x1a, 0xd0, 0x84, 0xca, 0x2c, 0xe4, 0x67, 0xee, 0x2b, 0x1e, 0x51, 0xf5, 0xab,
+	0xb2, 0x84, 0xc7, 0xef, 0x9e, 0xce, 0xb1, 0x59, 0x26, 0x4f, 0xad, 0x33, 0xc5, 0x99, 0x7f, 0xcb,
+	0x01, 0xd0, 0x87, 0xad, 0x97, 0x68, 0xd2, 0xa7, 0x50, 0x10, 0x8b, 0x19, 0x57, 0x77, 0xb5, 0x3b,
+	0x2a, 0xd5, 0x96, 0x0a, 0x3b, 0xc3, 0xc5, 0x8c, 0x33, 0xd2, 0x89, 0x23, 0xb4, 0xb6, 0x8c, 0xd0,
+	0xeb, 0xb1, 0x30, 0xa0, 0x3c, 0x09, 0x83, 0xd9, 0x8c, 0x4f, 0x54, 0x9f, 0x10, 0xb3, 0xe6, 0x31,
+	0x14, 0xf0, 0x5b, 0xcb, 0x04, 0x5b, 0x36, 0xdc, 0xb9, 0x65, 0xc3, 0x4d, 0x19, 0x66, 0x3d, 0x19,
+	0x74, 0x98, 0xa5, 0xe5, 0x51, 0x6c, 0x3d, 0xee, 0xb4, 0x86, 0x5a, 0x01, 0x49, 0x6a, 0xa8, 0xb5,
+	0xa2, 0xd9, 0x86, 0xc6, 0x60, 0x7e, 0xea, 0xb9, 0x51, 0xe2, 0x1c, 0x03, 0xca, 0xe3, 0x73, 0xc7,
+	0xf7, 0xb9, 0xa7, 0xf2, 0x27, 0x66, 0xd3, 0xa7, 0xa7, 0xcc, 0xa2, 0x98, 0x35, 0xbf, 0x80, 0x9b,
+	0xc9, 0x57, 0x54, 0x0a, 0xbe, 0x8f, 0x55, 0x7d, 0xcc, 0x5d, 0xbc, 0xf9, 0xa8, 0x2e, 0x69, 0x29,
+	0x30, 0xff, 0x9e, 0x03, 0xcd, 0x9e, 0x9f, 0x46, 0xe3, 0xd0, 0x3d, 0x4d, 0x2e, 0xbc, 0x78, 0x48,
+	0xc8, 0xa9, 0xe2, 0xd0, 0x24, 0x3c, 0x85, 0xc7, 0x11, 0x82, 0x87, 0xfe, 0x32, 0x3c, 0x8a, 0xd7,
+	0x5b, 0x50, 0x8b, 0xbc, 0xe0, 0x62, 0x34, 0x0b, 0x3c, 0x77, 0xbc, 0x20, 0x0f, 0x36, 0x76, 0xcd,
+	0xb8, 0x93, 0xb8, 0x34, 0xcb, 0x8e, 0xed, 0x05, 0x17, 0x03, 0xd2, 0x64, 0x10, 0x25, 0x74, 0x2a,
+	0xc6, 0x85, 0x95, 0x18, 0x7f, 0x02, 0xb0, 0x44, 0x60, 0x8b, 0xdd, 0x66, 0x74, 0x3b, 0x6a, 0x00,
+	0xb4, 0x3b, 0x76, 0xeb, 0xa4, 0xdf, 0xc7, 0x9b, 0x48, 0xce, 0x7c, 0x01, 0xe5, 0x9e, 0xea, 0x25,
+	0xde, 0xea, 0x41, 0x65, 0xb5, 0x8a, 0x7c, 0xcc, 0xa6, 0x7d, 0x9b, 0x5f, 0xf1, 0xed, 0x5b, 0xb2,
+	0xe0, 0x4f, 0x39, 0xd0, 0x98, 0x23, 0x78, 0xd7, 0x9d, 0xba, 0xe2, 0xcd, 0x27, 0xa1, 0x0e, 0x05,
+	0xba, 0x53, 0xae, 0xc9, 0x8b, 0x30, 0xd2, 0xfa, 0x7b, 0x50, 0x9d, 0xf1, 0xd0, 0x0d, 0x26, 0xd8,
+	0x67, 0xca, 0x94, 0xab, 0x48, 0x41, 0x8f, 0x4e, 0x95, 0xd3, 0x79, 0x18, 0x09, 0x55, 0x05, 0x24,
+	0x23, 0xfb, 0xa9, 0x48, 0x5e, 0xfa, 0xf3, 0x8c, 0x68, 0xac, 0x03, 0xe3, 0x73, 0x3e, 0x7e, 0x3e,
+	0x0a, 0x7c, 0x6f, 0x11, 0xd7, 0x01, 0x92, 0x9c, 0xf8, 0xde, 0xc2, 0xfc, 0x7d, 0x0e, 0x6e, 0xa5,
+	0x0c, 0x4c, 0x75, 0x09, 0x9e, 0x17, 0x5c, 0xa4, 0xba, 0x04, 0xc9, 0xca, 0x9c, 0x99, 0x3a, 0xae,
+	0xef, 0xfa, 0xcf, 0x94, 0xb9, 0x4b, 0x01, 0x36, 0xc8, 0x21, 0x17, 0xe1, 0x62, 0xe4, 0x9c, 0x09,
+	0x1e, 0x2e, 0x0d, 0xaf, 0x93, 0x74, 0x1f, 0x85, 0xbd, 0x48, 0x6a, 0xe1, 0x99, 0x91, 0x68, 0x15,
+	0x62, 0xad, 0x88, 0x0b, 0xa5, 0x65, 0xfe, 0x0a, 0x6a, 0xdd, 0x60, 0xfc, 0xfc, 0xad, 0xed, 0x43,
+	0x70, 0xe1, 0xf3, 0x50, 0xc5, 0x49, 0x32, 0xa9, 0x06, 0x3f, 0x7f, 0xa9, 0xc1, 0xbf, 0x70, 0x5c,
+	0xa1, 0x9a, 0x05, 0xa2, 0xcd, 0x2e, 0xd4, 0xe5, 0x0c, 0x6f, 0x2b, 0xc9, 0x57, 0x4c, 0x81, 0x77,
+	0x12, 0xee, 0x8f, 0xe3, 0xdb, 0x82, 0x64, 0xcc, 0x7f, 0xe6, 0xa0, 0xfe, 0xff, 0x73, 0x3e, 0xe7,
+	0x6f, 0x0d, 0xf3, 0x69, 0x30, 0x59, 0xa8, 0xad, 0x49, 0x34, 0xd6, 0xcf, 0x09, 0xf7, 0x9c, 0xc5,
+	0xd2, 0xe2, 0x32, 0xf1, 0xbd, 0x95, 0x42, 0xaf, 0xda, 0x81, 0x8f, 0x60, 0xfd, 0xa5, 0x1b, 0xb9,
+	0xa7, 0xae, 0xe7, 0x8a, 0x45, 0xea, 0xfe, 0xb1, 0x14, 0xf6, 0x22, 0x6c, 0xa0, 0xa6, 0xce, 0xab,
+	0x91, 0xda, 0xc9, 0x11, 0xc5, 0x7c, 0x9d, 0xd5, 0xa6, 0xce, 0x2b, 0xa6, 0x44, 0x58, 0xfd, 0x27,
+	0xdc, 0x99, 0x8c, 0x3c, 0x8e, 0xa9, 0x4d, 0xd5, 0xbf, 0xca, 0x00, 0x45, 0x5d, 0x92, 0xc8, 0x07,
+	0x92, 0x31, 0x77, 0x67, 0x82, 0x4e, 0x80, 0x02, 0x8b, 0x59, 0xf3, 0x37, 0xb0, 0xae, 0x56, 0xf9,
+	0x46, 0xaf, 0x35, 0x60, 0xcd, 0x9d, 0xa8, 0xfb, 0xd5, 0x9a, 0x3b, 0x49, 0x96, 0x9d, 0x4f, 0x2d,
+	0x3b, 0x35, 0x41, 0x61, 0x65, 0x02, 0x2c, 0x23, 0x89, 0xe9, 0x45, 0x32, 0x3d, 0xe1, 0xcd, 0x06,
+	0xd4, 0x6d, 0xe1, 0x88, 0x48, 0xb9, 0xd8, 0xfc, 0x6b, 0x0e, 0xd6, 0x95, 0x40, 0x59, 0xb3, 0xb9,
+	0x6c, 0xe9, 0x29, 0x36, 0xc4, 0xe8, 0x5b, 0x50, 0x8b, 0xe2, 0x22, 0x13, 0xc6, 0x57, 0xbf, 0xb4,
+	0x68, 0xa5, 0xb0, 0xa9, 0xcd, 0x76, 0x65, 0x61, 0x2b, 0xa8, 0x8d, 0xa8, 0x78, 0x1c, 0xa3, 0x7e,
+	0x9b, 0x87, 0xd2, 0xda, 0x02, 0x4b, 0x78, 0xda, 0xa4, 0x0b, 0xa1, 0x22, 0x50, 0x60, 0x92, 0xd9,
+	0xfd, 0x87, 0x09, 0x45, 0x6a, 0x9a, 0xf5, 0xff, 0x81, 0x92, 0x2d, 0x42, 0xee, 0x4c, 0xf5, 0xab,
+	0x7a, 0xfe, 0xe6, 0x95, 0x1d, 0xb6, 0x79, 0x63, 0x3b, 0xf7, 0x65, 0x4e, 0xdf, 0x83, 0x42, 0xcb,
+	0xf1, 0xbc, 0x4c, 0x40, 0x7d, 0x17, 0xf2, 0x36, 0x17, 0x99, 0x31, 0xd8, 0x52, 0x67, 0xc5, 0x1c,
+	0x65, 0x9d, 0x67, 0x0f, 0x0a, 0x47, 0x5c, 0x64, 0x9f, 0x68, 0x7f, 0x32, 0xc9, 0x86, 0x79, 0x00,
+	0x65, 0xc6, 0x67, 0x9e, 0x33, 0xe6, 0xd9, 0x70, 0xf7, 0xa1, 0xd4, 0xe6, 0x1e, 0x17, 0x19, 0x61,
+	0x5f, 0x43, 0x71, 0x48, 0xbd, 0x7d, 0xd6, 0xc9, 0xf6, 0xe5, 0x33, 0x6f, 0xd6, 0xb5, 0x0d, 0x42,
+	0x9e, 0x1d, 0xf7, 0x0d, 0x54, 0x3b, 0xc9, 0x03, 0x71, 0x56, 0x64, 0x9b, 0x5f, 0x0b, 0xf9, 0x10,
+	0x2a, 0x87, 0xde, 0x3c, 0x3a, 0xdf, 0xcf, 0x9a, 0xc5, 0x0f, 0xa0, 0xdc, 0xf1, 0xa3, 0x19, 0x1f,
+	0x67, 0xcf, 0xb0, 0x01, 0xe7, 0xcf, 0x33, 0x83, 0x8e, 0xed, 0x6b, 0xe4, 0xf2, 0xf1, 0x75, 0x36,
+	0xc0, 0x71, 0x9b, 0x67, 0xf7, 0x05, 0xce, 0x74, 0x1d, 0x1f, 0x1e, 0x63, 0xc4, 0x0f, 0x16, 0xd9,
+	0x8d, 0xec, 0x72, 0x3f, 0xf3, 0x16, 0xe8, 0x0e, 0xe6, 0xd1, 0x79, 0x66, 0x14, 0xcb, 0x8e, 0xda,
+	0xc3, 0xa7, 0xe0, 0x60, 0x96, 0x19, 0xc4, 0x32, 0x83, 0xee, 0xe3, 0x03, 0xb3, 0xe3, 0x3f, 0xcb,
+	0x5e, 0x0f, 0xba, 0xc3, 0xd0, 0x9d, 0x66, 0x5f, 0x56, 0x66, 0xbf, 0xef, 0xe1, 0x0b, 0xf7, 0x64,
+	0x92, 0x1d, 0xc4, 0xf8, 0x34, 0x73, 0x15, 0xb0, 0x3b, 0x91, 0x7a, 0xec, 0xcd, 0x5a, 0x05, 0x6c,
+	0x89, 0x8b, 0x32, 0xfb, 0xd1, 0x6e, 0x39, 0xe1, 0x24, 0x73, 0xd0, 0xec, 0x8e, 0x2f, 0x78, 0x98,
+	0x1d, 0xf6, 0xc8, 0xc7, 0x9f, 0x5e, 0x32, 0xdb, 0x88, 0xbf, 0xe0, 0x64, 0x8e, 0xc0, 0xd3, 0xeb,
+	0x9c, 0x6a, 0x4f, 0xaf, 0xb9, 0xa1, 0x9f, 0x66, 0x0e, 0xf7, 0x7d, 0xfc, 0xe9, 0x22, 0x7b, 0xea,
+	0x7f, 0x0f, 0xeb, 0x12, 0x76, 0xb0, 0xa0, 0xb7, 0xe3, 0xcc, 0xce, 0x44, 0xf4, 0xf3, 0xec, 0xa8,
+	0xec, 0x69, 0xf2, 0x35, 0xfd, 0xcc, 0x72, 0x8d, 0x18, 0x0c, 0x0e, 0x5b, 0xc1, 0xdc, 0x17, 0xd7,
+	0xc0, 0xf5, 0x78, 0x98, 0xd5, 0x9f, 0xdf, 0xd0, 0xef, 0x3c, 0x3c, 0xe2, 0xe1, 0xcb, 0xec, 0xbe,
+	0x3c, 0xc8, 0xbe, 0xbe, 0x87, 0xf4, 0xeb, 0x91, 0x7c, 0xb9, 0xcf, 0x6e, 0x68, 0xef, 0x3a, 0xc8,
+	0xfb, 0x50, 0x3a, 0xe2, 0x22, 0xf3, 0x01, 0x2c, 0x61, 0x99, 0x4f, 0x53, 0xdc, 0xaf, 0x5c, 0xf4,
+	0x9f, 0x64, 0x76, 0xcb, 0x11, 0x17, 0xd7, 0xd8, 0x0f, 0x58, 0xfb, 0xae, 0x05, 0xbc, 0x4f, 0xf7,
+	0x86, 0xcc, 0xe7, 0x81, 0x34, 0x94, 0x7e, 0xc8, 0x48, 0x80, 0xe9, 0x9f, 0x43, 0x9a, 0x9b, 0xab,
+	0xc2, 0x4b, 0x86, 0x5e, 0x03, 0xf8, 0x2d, 0xd4, 0x64, 0xcf, 0x7c, 0x0d, 0xec, 0x21, 0xd4, 0x52,
+	0xbf, 0x5f, 0xe8, 0xef, 0x2a, 0xb5, 0xd7, 0x7f, 0x42, 0x69, 0x36, 0xaf, 0x1a, 0x5a, 0xc9, 0xf5,
+	0xec, 0x2d, 0x01, 0xa2, 0xd8, 0x35, 0x50, 0x65, 0x7c, 0xe3, 0xc5, 0xde, 0x4f, 0x7f, 0xfd, 0x15,
+	0xbb, 0xb9, 0xb1, 0x22, 0xbb, 0x8c, 0xb2, 0xb3, 0xa1, 0x1e, 0x02, 0xa0, 0x44, 0xdd, 0x49, 0x32,
+	0x00, 0xbf, 0x83, 0x06, 0x4a, 0x96, 0xaf, 0xd7, 0x59, 0xc0, 0x7b, 0x50, 0xa4, 0xc7, 0xd2, 0xc4,
+	0x2f, 0xe9, 0x47, 0xdb, 0xe6, 0xad, 0xd7, 0xde, 0x53, 0xcd, 0x1b, 0x5f, 0xe6, 0xf4, 0x6f, 0xa1,
+	0xac, 0x9e, 0x1e, 0xf5, 0xdb, 0x4a, 0x63, 0xf5, 0x41, 0xb3, 0x79, 0xe7, 0xb2, 0x78, 0xa5, 0xb5,
+	0x88, 0xaf, 0xe9, 0xfa, 0x3b, 0x6f, 0x78, 0x30, 0x6c, 0x36, 0x12, 0x6b, 0xe5, 0x63, 0x27, 0xce,
+	0xfa, 0xbf, 0x50, 0x4d, 0x1e, 0xb6, 0x12, 0xe4, 0xe5, 0xb7, 0xb8, 0xa6, 0xf1, 0xfa, 0x40, 0x32,
+	0xf7, 0x57, 0x50, 0xc0, 0xf7, 0xa1, 0xc4, 0x3f, 0xa9, 0xe7, 0xa8, 0xe6, 0xc6, 0x8a, 0x2c, 0xe5,
+	0x9f, 0xd2, 0x23, 0xdf, 0xcb, 0x08, 0xda, 0x85, 0x22, 0xe3, 0x3e, 0xbf, 0xc8, 0x82, 0x79, 0x00,
+	0x65, 0xcb, 0x7f, 0x31, 0xe7, 0xf3, 0x65, 0xed, 0x48, 0x3f, 0x3e, 0x35, 0x37, 0x57, 0x85, 0xab,
+	0xd7, 0x5f, 0x7a, 0x4d, 0xc9, 0x86, 0xc3, 0xab, 0xf6, 0xf8, 0x79, 0x36, 0xcc, 0x1e, 0x14, 0xfa,
+	0x4e, 0x56, 0x10, 0x16, 0x61, 0xe1, 0xa4, 0x0e, 0x8a, 0xf4, 0x83, 0x4f, 0x73, 0x73, 0x55, 0x18,
+	0xa3, 0x4e, 0x4b, 0xf4, 0x07, 0xb0, 0xbd, 0x7f, 0x0f, 0x00, 0xc3, 0x45, 0x26, 0xbd, 0x0f, 0x26,
+	0x00, 0x00,
 }
@@ -0,0 +1,496 @@
+/*
+
+Package disk implements a small log-structured, on-disk key/value store meant
+to be used as a second tier behind an lru.Cache. Items evicted from memory via
+LRU are appended to a single log file and an in-memory index maps each key to
+its latest record. Deletes (including the delete issued when an item is
+promoted back into memory) append a tombstone, so the log can be replayed on
+Open to rebuild the index.
+
+The store has its own size limit. When the log grows past it, the store is
+compacted: live, unexpired records are rewritten to a fresh log, and if they
+still don't fit within the limit the oldest records are dropped. Compaction
+runs in the background, copying records without holding the store's lock, so
+a Put (made under the cache's lock when an item is evicted) never waits for
+the log to be rewritten; the log can briefly exceed the limit while it is.
+Only the standard library is used.
+
+		store, err := disk.Open("/var/cache/grpc-cache", 1<<30)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer store.Close()
+		myCache := lru.New(1000).WithTier(store)
+
+Unlike lru.Cache, a Store is safe for concurrent use.
+
+*/
+package disk
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is the error returned when a key isn't in the store.
+var ErrNotFound = errors.New("item not found")
+
+// ErrCorrupt is the error returned when a record fails its checksum.
+var ErrCorrupt = errors.New("corrupt record")
+
+const (
+	logName     = "spill.log"
+	compactName = "spill.log.compact"
+
+	// crc32 | flags | key length | value length | cas | expiresAt
+	headerSize = 4 + 1 + 4 + 4 + 8 + 8
+
+	flagTombstone = 1 << 0
+
+	// compactTarget is the fraction of maxBytes that live data is trimmed to
+	// during compaction, leaving headroom so the next few writes don't
+	// immediately trigger another compaction.
+	compactTarget = 0.75
+)
+
+// record is the location of a key's latest value in the log.
+type record struct {
+	offset    int64
+	size      int64
+	expiresAt int64
+}
+
+// Store is an on-disk, log-structured key/value store.
+type Store struct {
+	sync.Mutex
+	dir       string
+	maxBytes  int64
+	file      *os.File
+	size      int64
+	liveBytes int64
+	index     map[string]record
+	// compacting is true while a compaction is running. generation is bumped
+	// by Flush, so a compaction running across one knows to give up.
+	compacting  bool
+	generation  int
+	compactions sync.WaitGroup
+}
+
+// Open opens (or creates) the store in dir, replaying any existing log to
+// rebuild the index. maxBytes is the size limit of the log; set it to 0 for
+// unlimited.
+func Open(dir string, maxBytes int64) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, logName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{
+		dir:      dir,
+		maxBytes: maxBytes,
+		file:     file,
+		index:    make(map[string]record),
+	}
+	if err := s.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close waits for any background compaction and closes the underlying log
+// file.
+func (s *Store) Close() error {
+	s.compactions.Wait()
+	s.Lock()
+	defer s.Unlock()
+	return s.file.Close()
+}
+
+// Len returns the number of keys currently in the store, including any that
+// have expired but haven't yet been compacted away.
+func (s *Store) Len() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.index)
+}
+
+// Size returns the current size of the log in bytes.
+func (s *Store) Size() int64 {
+	s.Lock()
+	defer s.Unlock()
+	return s.size
+}
+
+// Put stores the item, replacing any previous value for key. A zero expiresAt
+// means the item never expires.
+func (s *Store) Put(key string, value []byte, cas uint64, expiresAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	var exp int64
+	if !expiresAt.IsZero() {
+		exp = expiresAt.UnixNano()
+	}
+	rec, err := s.append(0, key, value, cas, exp)
+	if err != nil {
+		return err
+	}
+	if old, ok := s.index[key]; ok {
+		s.liveBytes -= old.size
+	}
+	s.index[key] = rec
+	s.liveBytes += rec.size
+
+	if s.maxBytes != 0 && s.size > s.maxBytes && !s.compacting {
+		// an error just leaves the log as it is, to be compacted by a later
+		// Put
+		s.compacting = true
+		s.compactions.Add(1)
+		go func() {
+			defer s.compactions.Done()
+			s.compact()
+		}()
+	}
+	return nil
+}
+
+// Get returns the value, cas and expiration time for key. Expired items are
+// reported as ErrNotFound.
+func (s *Store) Get(key string) ([]byte, uint64, time.Time, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	rec, ok := s.index[key]
+	if !ok {
+		return nil, 0, time.Time{}, ErrNotFound
+	}
+	if expired(rec.expiresAt) {
+		s.remove(key)
+		return nil, 0, time.Time{}, ErrNotFound
+	}
+	buf := make([]byte, rec.size)
+	if _, err := s.file.ReadAt(buf, rec.offset); err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	_, k, value, cas, exp, err := decode(buf)
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	if k != key {
+		return nil, 0, time.Time{}, ErrCorrupt
+	}
+	var expiresAt time.Time
+	if exp != 0 {
+		expiresAt = time.Unix(0, exp)
+	}
+	return value, cas, expiresAt, nil
+}
+
+// Delete removes key from the store. Deleting a key that doesn't exist is not
+// an error.
+func (s *Store) Delete(key string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.index[key]; !ok {
+		return nil
+	}
+	if _, err := s.append(flagTombstone, key, nil, 0, 0); err != nil {
+		return err
+	}
+	s.remove(key)
+	return nil
+}
+
+// Flush removes everything from the store.
+func (s *Store) Flush() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	s.size = 0
+	s.liveBytes = 0
+	s.index = make(map[string]record)
+	s.generation++
+	return nil
+}
+
+// Compact rewrites the log so that it only contains live, unexpired records,
+// dropping the oldest records if they don't fit within the size limit. If a
+// background compaction is already running, it waits for that one instead.
+func (s *Store) Compact() error {
+	s.Lock()
+	if s.compacting {
+		s.Unlock()
+		s.compactions.Wait()
+		return nil
+	}
+	s.compacting = true
+	s.Unlock()
+	return s.compact()
+}
+
+// remove drops key from the index.
+func (s *Store) remove(key string) {
+	if rec, ok := s.index[key]; ok {
+		s.liveBytes -= rec.size
+		delete(s.index, key)
+	}
+}
+
+// append writes a record to the end of the log and returns its location.
+func (s *Store) append(flags byte, key string, value []byte, cas uint64, expiresAt int64) (record, error) {
+	buf := encode(flags, key, value, cas, expiresAt)
+	if _, err := s.file.WriteAt(buf, s.size); err != nil {
+		return record{}, err
+	}
+	rec := record{offset: s.size, size: int64(len(buf)), expiresAt: expiresAt}
+	s.size += rec.size
+	return rec, nil
+}
+
+// compact copies the live records into a new log and swaps it in place of the
+// old one. The caller sets compacting, and compact clears it. The lock is
+// only held to pick the records to keep and to swap the logs: the records are
+// copied without it, and anything written to the old log in the meantime is
+// copied across at the end.
+func (s *Store) compact() error {
+	s.Lock()
+	keys := make([]string, 0, len(s.index))
+	for key, rec := range s.index {
+		if expired(rec.expiresAt) {
+			s.remove(key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	// newest first, so that when the limit is hit it's the oldest records
+	// that are dropped
+	sort.Sort(newestFirst{keys, s.index})
+	budget := int64(-1)
+	if s.maxBytes != 0 {
+		budget = int64(float64(s.maxBytes) * compactTarget)
+	}
+	var used int64
+	for i, key := range keys {
+		if budget >= 0 && used+s.index[key].size > budget {
+			for _, dropped := range keys[i:] {
+				s.remove(dropped)
+			}
+			keys = keys[:i]
+			break
+		}
+		used += s.index[key].size
+	}
+	// and back to oldest first to preserve the log order
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	records := make([]record, len(keys))
+	for i, key := range keys {
+		records[i] = s.index[key]
+	}
+	old, end, generation := s.file, s.size, s.generation
+	s.Unlock()
+
+	path := filepath.Join(s.dir, compactName)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		s.compactDone()
+		return err
+	}
+	abandon := func(err error) error {
+		file.Close()
+		os.Remove(path)
+		s.compactDone()
+		return err
+	}
+	// the offsets of the kept records in the new log, by their old offset
+	moved := make(map[int64]int64, len(keys))
+	var size int64
+	for _, rec := range records {
+		buf := make([]byte, rec.size)
+		if _, err := old.ReadAt(buf, rec.offset); err != nil {
+			return abandon(err)
+		}
+		if _, err := file.WriteAt(buf, size); err != nil {
+			return abandon(err)
+		}
+		moved[rec.offset] = size
+		size += rec.size
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.compacting = false
+	if s.generation != generation {
+		// flushed in the meantime
+		file.Close()
+		os.Remove(path)
+		return nil
+	}
+	// records written since are appended as they are, shifted to follow the
+	// copied ones
+	shift := size - end
+	if s.size > end {
+		tail := make([]byte, s.size-end)
+		if _, err := s.file.ReadAt(tail, end); err != nil {
+			file.Close()
+			os.Remove(path)
+			return err
+		}
+		if _, err := file.WriteAt(tail, size); err != nil {
+			file.Close()
+			os.Remove(path)
+			return err
+		}
+		size += int64(len(tail))
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := os.Rename(path, filepath.Join(s.dir, logName)); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	// everything still in the index from before the compaction started was
+	// kept, as the rest was removed above, and is unchanged, as later writes
+	// go at the end
+	index := make(map[string]record, len(s.index))
+	var live int64
+	for key, rec := range s.index {
+		if rec.offset >= end {
+			rec.offset += shift
+		} else {
+			rec.offset = moved[rec.offset]
+		}
+		index[key] = rec
+		live += rec.size
+	}
+	s.file.Close()
+	s.file = file
+	s.size = size
+	s.liveBytes = live
+	s.index = index
+	return nil
+}
+
+// compactDone clears compacting after a compaction gives up before taking the
+// lock to swap the logs.
+func (s *Store) compactDone() {
+	s.Lock()
+	s.compacting = false
+	s.Unlock()
+}
+
+// replay reads the log from the start, rebuilding the index. A truncated or
+// corrupt record at the tail (from a crash mid-write, say) ends the replay and
+// is cut off.
+func (s *Store) replay() error {
+	info, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(io.NewSectionReader(s.file, 0, 1<<62))
+	var offset int64
+	header := make([]byte, headerSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			break
+		}
+		keyLen := int64(binary.LittleEndian.Uint32(header[5:9]))
+		valueLen := int64(binary.LittleEndian.Uint32(header[9:13]))
+		// the lengths aren't covered by the checksum until the whole record
+		// has been read, so don't trust a corrupt header to size the buffer
+		if keyLen+valueLen > info.Size()-offset-headerSize {
+			break
+		}
+		buf := make([]byte, headerSize+keyLen+valueLen)
+		copy(buf, header)
+		if _, err := io.ReadFull(r, buf[headerSize:]); err != nil {
+			break
+		}
+		flags, key, _, _, exp, err := decode(buf)
+		if err != nil {
+			break
+		}
+		rec := record{offset: offset, size: int64(len(buf)), expiresAt: exp}
+		s.remove(key)
+		if flags&flagTombstone == 0 {
+			s.index[key] = rec
+			s.liveBytes += rec.size
+		}
+		offset += rec.size
+	}
+	s.size = offset
+	return s.file.Truncate(offset)
+}
+
+// newestFirst sorts keys by descending log offset.
+type newestFirst struct {
+	keys  []string
+	index map[string]record
+}
+
+func (n newestFirst) Len() int      { return len(n.keys) }
+func (n newestFirst) Swap(i, j int) { n.keys[i], n.keys[j] = n.keys[j], n.keys[i] }
+func (n newestFirst) Less(i, j int) bool {
+	return n.index[n.keys[i]].offset > n.index[n.keys[j]].offset
+}
+
+// encode serializes a record.
+func encode(flags byte, key string, value []byte, cas uint64, expiresAt int64) []byte {
+	buf := make([]byte, headerSize+len(key)+len(value))
+	buf[4] = flags
+	binary.LittleEndian.PutUint32(buf[5:9], uint32(len(key)))
+	binary.LittleEndian.PutUint32(buf[9:13], uint32(len(value)))
+	binary.LittleEndian.PutUint64(buf[13:21], cas)
+	binary.LittleEndian.PutUint64(buf[21:29], uint64(expiresAt))
+	copy(buf[headerSize:], key)
+	copy(buf[headerSize+len(key):], value)
+	binary.LittleEndian.PutUint32(buf[0:4], crc32.ChecksumIEEE(buf[4:]))
+	return buf
+}
+
+// decode deserializes a record, verifying its checksum.
+func decode(buf []byte) (flags byte, key string, value []byte, cas uint64, expiresAt int64, err error) {
+	if len(buf) < headerSize {
+		return 0, "", nil, 0, 0, ErrCorrupt
+	}
+	if binary.LittleEndian.Uint32(buf[0:4]) != crc32.ChecksumIEEE(buf[4:]) {
+		return 0, "", nil, 0, 0, ErrCorrupt
+	}
+	flags = buf[4]
+	keyLen := int(binary.LittleEndian.Uint32(buf[5:9]))
+	valueLen := int(binary.LittleEndian.Uint32(buf[9:13]))
+	if len(buf) != headerSize+keyLen+valueLen {
+		return 0, "", nil, 0, 0, ErrCorrupt
+	}
+	cas = binary.LittleEndian.Uint64(buf[13:21])
+	expiresAt = int64(binary.LittleEndian.Uint64(buf[21:29]))
+	key = string(buf[headerSize : headerSize+keyLen])
+	value = buf[headerSize+keyLen:]
+	return flags, key, value, cas, expiresAt, nil
+}
+
+// expired returns true if the expiration time (in unix nanoseconds) has
+// passed. Zero means no expiration.
+func expired(expiresAt int64) bool {
+	return expiresAt != 0 && time.Now().UnixNano() > expiresAt
+}
@@ -0,0 +1,198 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func tempStore(t *testing.T, maxBytes int64) (*Store, string) {
+	dir, err := ioutil.TempDir("", "grpc-cache-disk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(dir, maxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, dir
+}
+
+func TestPutGetDelete(t *testing.T) {
+	s, dir := tempStore(t, 0)
+	defer os.RemoveAll(dir)
+	defer s.Close()
+
+	if err := s.Put("foo", []byte("bar"), 7, time.Time{}); err != nil {
+		t.Fatalf("error putting foo: %s", err)
+	}
+	v, cas, expiresAt, err := s.Get("foo")
+	if err != nil {
+		t.Fatalf("error getting foo: %s", err)
+	}
+	if bytes.Compare(v, []byte("bar")) != 0 || cas != 7 || !expiresAt.IsZero() {
+		t.Fatalf("stored value doesn't look right: %s %d %s", v, cas, expiresAt)
+	}
+
+	// a second put replaces the first
+	s.Put("foo", []byte("baz"), 8, time.Time{})
+	if v, _, _, _ := s.Get("foo"); bytes.Compare(v, []byte("baz")) != 0 {
+		t.Fatalf("foo should have been replaced but was %s", v)
+	}
+
+	s.Delete("foo")
+	if _, _, _, err := s.Get("foo"); err != ErrNotFound {
+		t.Fatalf("foo should have been deleted: %v", err)
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	s, dir := tempStore(t, 0)
+	defer os.RemoveAll(dir)
+	defer s.Close()
+
+	s.Put("foo", []byte("bar"), 1, time.Now().Add(time.Millisecond))
+	time.Sleep(time.Millisecond * 5)
+	if _, _, _, err := s.Get("foo"); err != ErrNotFound {
+		t.Fatalf("foo should have expired: %v", err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	s, dir := tempStore(t, 0)
+	defer os.RemoveAll(dir)
+
+	s.Put("foo", []byte("bar"), 1, time.Time{})
+	s.Put("yuk", []byte("woof"), 2, time.Time{})
+	s.Put("foo", []byte("baz"), 3, time.Time{})
+	s.Delete("yuk")
+	s.Close()
+
+	// reopening replays the log: the latest foo survives and yuk stays deleted
+	s, err := Open(dir, 0)
+	if err != nil {
+		t.Fatalf("error reopening store: %s", err)
+	}
+	defer s.Close()
+	v, cas, _, err := s.Get("foo")
+	if err != nil || bytes.Compare(v, []byte("baz")) != 0 || cas != 3 {
+		t.Fatalf("foo wasn't replayed correctly: %s %d %v", v, cas, err)
+	}
+	if _, _, _, err := s.Get("yuk"); err != ErrNotFound {
+		t.Fatalf("yuk should still be deleted: %v", err)
+	}
+	if s.Len() != 1 {
+		t.Fatalf("expected 1 item after replay, got %d", s.Len())
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	s, dir := tempStore(t, 4096)
+	defer os.RemoveAll(dir)
+	defer s.Close()
+
+	value := bytes.Repeat([]byte("x"), 100)
+	for i := 0; i < 200; i++ {
+		if err := s.Put(fmt.Sprintf("key-%d", i), value, uint64(i), time.Time{}); err != nil {
+			t.Fatalf("error putting item %d: %s", i, err)
+		}
+		// compaction runs in the background, so the log can overshoot the
+		// limit while it does, but only by what's written in the meantime
+		s.compactions.Wait()
+		if s.Size() > 4096 {
+			t.Fatalf("log grew past its limit: %d", s.Size())
+		}
+	}
+
+	// the oldest items were dropped to stay under the limit, the newest kept
+	if _, _, _, err := s.Get("key-0"); err != ErrNotFound {
+		t.Fatal("key-0 should have been dropped by compaction")
+	}
+	if v, _, _, err := s.Get("key-199"); err != nil || bytes.Compare(v, value) != 0 {
+		t.Fatalf("key-199 should have survived compaction: %v", err)
+	}
+
+	// an explicit compaction of a store full of deleted items empties the log
+	for i := 0; i < 200; i++ {
+		s.Delete(fmt.Sprintf("key-%d", i))
+	}
+	if err := s.Compact(); err != nil {
+		t.Fatalf("error compacting: %s", err)
+	}
+	if s.Size() != 0 || s.Len() != 0 {
+		t.Fatalf("expected an empty store, got %d bytes and %d items", s.Size(), s.Len())
+	}
+}
+
+func TestCompactionConcurrentWrites(t *testing.T) {
+	s, dir := tempStore(t, 1<<16)
+	defer os.RemoveAll(dir)
+
+	// writes and deletes keep landing while compactions copy the log
+	value := bytes.Repeat([]byte("x"), 100)
+	for i := 0; i < 5000; i++ {
+		s.Put(fmt.Sprintf("key-%d", i%300), append(value, byte(i)), uint64(i), time.Time{})
+		if i%7 == 0 {
+			s.Delete(fmt.Sprintf("key-%d", (i+150)%300))
+		}
+	}
+	s.compactions.Wait()
+	check := func(s *Store) {
+		for i := 4700; i < 5000; i++ {
+			key := fmt.Sprintf("key-%d", i%300)
+			v, cas, _, err := s.Get(key)
+			if err == ErrNotFound {
+				continue
+			}
+			if err != nil || cas < 4700 || v[len(v)-1] != byte(cas) {
+				t.Fatalf("%s has the wrong value after compaction: %d %v", key, cas, err)
+			}
+		}
+	}
+	check(s)
+
+	// and the compacted log replays to the same thing
+	s.Close()
+	s, err := Open(dir, 1<<16)
+	if err != nil {
+		t.Fatalf("error reopening store: %s", err)
+	}
+	defer s.Close()
+	check(s)
+}
+
+func TestReplayCorruptLength(t *testing.T) {
+	s, dir := tempStore(t, 0)
+	defer os.RemoveAll(dir)
+	s.Put("foo", []byte("bar"), 1, time.Time{})
+	s.Close()
+
+	// a header claiming a huge record at the tail is cut off rather than
+	// allocated
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(header[5:9], 1<<31)
+	binary.LittleEndian.PutUint32(header[9:13], 1<<31)
+	f, err := os.OpenFile(filepath.Join(dir, logName), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(header)
+	f.Close()
+
+	s, err = Open(dir, 0)
+	if err != nil {
+		t.Fatalf("error reopening store: %s", err)
+	}
+	defer s.Close()
+	if v, _, _, err := s.Get("foo"); err != nil || string(v) != "bar" {
+		t.Fatalf("foo wasn't replayed: %s %v", v, err)
+	}
+	if s.Size() != int64(headerSize+len("foo")+len("bar")) {
+		t.Fatalf("the corrupt tail wasn't cut off: %d", s.Size())
+	}
+}
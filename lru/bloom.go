@@ -0,0 +1,190 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// A Bloom filter is stored as a plain value: bloomMagic, the number of hash
+// functions and the number of bits (both uint32, little endian), followed by
+// the bits.
+
+const (
+	bloomMagic  = "BLM1"
+	bloomHeader = len(bloomMagic) + 8
+
+	// the filter created by BFAdd on a missing key
+	bloomDefaultCapacity  = 100
+	bloomDefaultErrorRate = 0.01
+
+	// the largest filter BFReserve will create: 16MB, enough for about 14
+	// million items at a 1% error rate, and 64 hashes, for an error rate of
+	// about 1e-19
+	bloomMaxBits   = 1 << 27
+	bloomMaxHashes = 64
+)
+
+type bloom struct {
+	hashes uint32
+	bits   uint32
+	filter []byte
+}
+
+// newBloom returns the encoding of an empty Bloom filter sized for capacity
+// items at the given false positive rate. A filter larger than bloomMaxBits or
+// needing more than bloomMaxHashes is ErrInvalid.
+func newBloom(capacity uint64, errorRate float64) ([]byte, error) {
+	if capacity == 0 || errorRate <= 0 || errorRate >= 1 {
+		return nil, ErrInvalid
+	}
+	bits := math.Ceil(-float64(capacity) * math.Log(errorRate) / (math.Ln2 * math.Ln2))
+	if bits > bloomMaxBits {
+		return nil, ErrInvalid
+	}
+	hashes := math.Ceil(math.Ln2 * bits / float64(capacity))
+	if hashes > bloomMaxHashes {
+		return nil, ErrInvalid
+	}
+	value := make([]byte, bloomHeader+(int(bits)+7)/8)
+	copy(value, bloomMagic)
+	binary.LittleEndian.PutUint32(value[len(bloomMagic):], uint32(hashes))
+	binary.LittleEndian.PutUint32(value[len(bloomMagic)+4:], uint32(bits))
+	return value, nil
+}
+
+// decodeBloom returns the Bloom filter in value, aliasing it, or false if
+// value isn't a Bloom filter.
+func decodeBloom(value []byte) (bloom, bool) {
+	if len(value) < bloomHeader || !bytes.HasPrefix(value, []byte(bloomMagic)) {
+		return bloom{}, false
+	}
+	b := bloom{
+		hashes: binary.LittleEndian.Uint32(value[len(bloomMagic):]),
+		bits:   binary.LittleEndian.Uint32(value[len(bloomMagic)+4:]),
+		filter: value[bloomHeader:],
+	}
+	if b.hashes == 0 || b.bits == 0 || len(b.filter) != (int(b.bits)+7)/8 {
+		return bloom{}, false
+	}
+	return b, true
+}
+
+// positions calls fn with each bit position for item, using double hashing
+// on the two halves of its hash.
+func (b bloom) positions(item []byte, fn func(bit uint64) bool) {
+	h := hash64(item)
+	h1, h2 := h&0xffffffff, h>>32
+	for i := uint64(0); i < uint64(b.hashes); i++ {
+		if !fn((h1 + i*h2) % uint64(b.bits)) {
+			return
+		}
+	}
+}
+
+// add sets the bits for item and returns true if any weren't already set.
+func (b bloom) add(item []byte) bool {
+	added := false
+	b.positions(item, func(bit uint64) bool {
+		if b.filter[bit/8]&(1<<(bit%8)) == 0 {
+			b.filter[bit/8] |= 1 << (bit % 8)
+			added = true
+		}
+		return true
+	})
+	return added
+}
+
+// test returns true if all of the bits for item are set.
+func (b bloom) test(item []byte) bool {
+	found := true
+	b.positions(item, func(bit uint64) bool {
+		found = b.filter[bit/8]&(1<<(bit%8)) != 0
+		return found
+	})
+	return found
+}
+
+// getBloom returns the Bloom filter stored at key, aliasing the stored value,
+// and its entry index. A key holding something other than a Bloom filter is
+// ErrWrongType.
+func (c *Cache) getBloom(key string) (bloom, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		return bloom{}, nilIndex, ErrNotFound
+	}
+	if c.isObject(i) {
+		return bloom{}, nilIndex, ErrWrongType
+	}
+	b, ok := decodeBloom(c.value(&c.entries[i]))
+	if !ok {
+		return bloom{}, nilIndex, ErrWrongType
+	}
+	return b, i, nil
+}
+
+// BFReserve creates an empty Bloom filter at key sized to hold capacity items
+// with the given false positive rate, between 0 and 1. If the key already
+// exists, it returns ErrExists, and an invalid capacity or error rate,
+// including one that would need a filter larger than 16MB, is ErrInvalid.
+func (c *Cache) BFReserve(key string, capacity uint64, errorRate float64, ttl time.Duration) error {
+	if c.lookup(key) != nilIndex {
+		return ErrExists
+	}
+	value, err := newBloom(capacity, errorRate)
+	if err != nil {
+		return err
+	}
+	c.Set(key, value, ttl)
+	return nil
+}
+
+// BFAdd adds item to the Bloom filter stored at key and returns true if it
+// wasn't (as far as the filter can tell) already there. A missing key gets a
+// filter for 100 items with a 1% error rate. If ttl is not zero, the TTL of
+// the filter is set to it.
+func (c *Cache) BFAdd(key string, item []byte, ttl time.Duration) (bool, error) {
+	b, i, err := c.getBloom(key)
+	if err == ErrNotFound {
+		value, _ := newBloom(bloomDefaultCapacity, bloomDefaultErrorRate)
+		b, _ = decodeBloom(value)
+		b.add(item)
+		c.Set(key, value, ttl)
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	added := b.add(item)
+	if added || ttl != 0 {
+		c.valueUpdated(i, ttl)
+	}
+	return added, nil
+}
+
+// BFExists returns true if item may have been added to the Bloom filter stored
+// at key, and false if it definitely hasn't. A missing key is an empty filter.
+func (c *Cache) BFExists(key string, item []byte) (bool, error) {
+	exists, err := c.BFMExists(key, item)
+	if err != nil {
+		return false, err
+	}
+	return exists[0], nil
+}
+
+// BFMExists is BFExists for several items at once.
+func (c *Cache) BFMExists(key string, items ...[]byte) ([]bool, error) {
+	b, _, err := c.getBloom(key)
+	exists := make([]bool, len(items))
+	if err == ErrNotFound {
+		return exists, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for j, item := range items {
+		exists[j] = b.test(item)
+	}
+	return exists, nil
+}
@@ -0,0 +1,173 @@
+package lru
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// hash is a hash value: a map of fields to values, stored as a single cache
+// item with a single TTL.
+type hash struct {
+	fields map[string][]byte
+	bytes  int
+}
+
+func (h *hash) size() int {
+	return h.bytes
+}
+
+// set sets the field, returning true if it's new.
+func (h *hash) set(field string, value []byte) bool {
+	old, ok := h.fields[field]
+	if ok {
+		h.bytes -= len(old)
+	} else {
+		h.bytes += len(field) + elementOverhead
+	}
+	h.fields[field] = append([]byte(nil), value...)
+	h.bytes += len(value)
+	return !ok
+}
+
+// del deletes the field, returning true if it existed.
+func (h *hash) del(field string) bool {
+	old, ok := h.fields[field]
+	if ok {
+		delete(h.fields, field)
+		h.bytes -= len(field) + elementOverhead + len(old)
+	}
+	return ok
+}
+
+// getHash returns the hash stored at key and its entry index. With create, a
+// missing key gets a new, empty hash. A key holding something other than a
+// hash is ErrWrongType.
+func (c *Cache) getHash(key string, create bool) (*hash, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !create {
+			return nil, nilIndex, ErrNotFound
+		}
+		h := &hash{fields: make(map[string][]byte)}
+		return h, c.insertObject(key, h), nil
+	}
+	h, ok := c.objects[i].(*hash)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return h, i, nil
+}
+
+// HSet sets fields in the hash stored at key, creating the hash if it doesn't
+// exist, and returns the number of fields that were added (rather than
+// updated). If ttl is not zero, the TTL of the whole hash is set to it.
+func (c *Cache) HSet(key string, fields map[string][]byte, ttl time.Duration) (int, error) {
+	if len(fields) == 0 {
+		return 0, nil
+	}
+	h, i, err := c.getHash(key, true)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for field, value := range fields {
+		if h.set(field, value) {
+			added++
+		}
+	}
+	c.valueUpdated(i, ttl)
+	return added, nil
+}
+
+// HGet gets the value of field in the hash stored at key. A missing key or
+// field is ErrNotFound.
+func (c *Cache) HGet(key string, field string) ([]byte, error) {
+	h, _, err := c.getHash(key, false)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := h.fields[field]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+// HDel deletes fields from the hash stored at key and returns the number that
+// existed. A hash left with no fields is deleted.
+func (c *Cache) HDel(key string, fields ...string) (int, error) {
+	h, i, err := c.getHash(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, field := range fields {
+		if h.del(field) {
+			deleted++
+		}
+	}
+	if len(h.fields) == 0 {
+		c.removeEntry(i)
+		return deleted, nil
+	}
+	if deleted != 0 {
+		c.valueUpdated(i, 0)
+	}
+	return deleted, nil
+}
+
+// HGetAll returns all of the fields and values in the hash stored at key.
+func (c *Cache) HGetAll(key string) (map[string][]byte, error) {
+	h, _, err := c.getHash(key, false)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string][]byte, len(h.fields))
+	for field, value := range h.fields {
+		fields[field] = append([]byte(nil), value...)
+	}
+	return fields, nil
+}
+
+// HIncrBy adds delta to the integer value of field in the hash stored at key
+// and returns the new value. Missing hashes and fields start at 0. Unlike the
+// counters used by Increment and Decrement, hash field integers are stored as
+// decimal strings, and a field that doesn't hold one is ErrNotInteger. A
+// result that wouldn't fit in an int64 is ErrOverflow, and leaves the field as
+// it was.
+func (c *Cache) HIncrBy(key string, field string, delta int64) (int64, error) {
+	h, i, err := c.getHash(key, true)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	if value, ok := h.fields[field]; ok {
+		if n, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+			return 0, ErrNotInteger
+		}
+	}
+	if (delta > 0 && n > math.MaxInt64-delta) || (delta < 0 && n < math.MinInt64-delta) {
+		return 0, ErrOverflow
+	}
+	n += delta
+	h.set(field, []byte(strconv.FormatInt(n, 10)))
+	c.valueUpdated(i, 0)
+	return n, nil
+}
+
+// HLen returns the number of fields in the hash stored at key, or 0 if it
+// doesn't exist.
+func (c *Cache) HLen(key string) (int, error) {
+	h, _, err := c.getHash(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(h.fields), nil
+}
@@ -0,0 +1,150 @@
+package lru
+
+// The key index maps keys to entries. It's an open addressing hash table with
+// linear probing, and like the LRU list and the slabs it holds no pointers,
+// so it adds nothing for the garbage collector to scan.
+//
+// Each slot keeps the location of its entry's slab chunk and a tag from the
+// key's hash alongside the entry's index, so a lookup can compare the key as
+// soon as it has read the slot, rather than having to read the entry first to
+// find the chunk. Reads of large caches mostly wait on memory, and this way
+// the chunk and the entry can be fetched at the same time.
+
+const (
+	// minIndexSlots is the number of slots the index starts with.
+	minIndexSlots = 64
+	// indexLoad is the fraction of slots, in quarters, that may be in use
+	// before the index grows.
+	indexLoad = 3
+)
+
+// indexSlot is a slot of the key index. It's empty if entry is nilIndex.
+type indexSlot struct {
+	ref   slabRef
+	tag   uint32
+	entry int32
+}
+
+// keyIndex is the key index's table. The number of slots is a power of two.
+type keyIndex struct {
+	slots []indexSlot
+	// shift turns a hash into a slot number (see home)
+	shift uint
+	count int
+}
+
+// newKeyIndex returns an empty index with n slots, which must be a power of
+// two.
+func newKeyIndex(n int) keyIndex {
+	x := keyIndex{slots: make([]indexSlot, n), shift: 64}
+	for i := range x.slots {
+		x.slots[i].entry = nilIndex
+	}
+	for ; n > 1; n >>= 1 {
+		x.shift--
+	}
+	return x
+}
+
+// home returns the slot a key with hash h is placed in if it's free. The
+// hash is scrambled first (Fibonacci hashing), since FNV's low bits alone
+// cluster badly under linear probing.
+func (x *keyIndex) home(h uint64) int {
+	return int((h * 0x9E3779B97F4A7C15) >> x.shift)
+}
+
+// find returns the index of the entry for key, or nilIndex if it isn't in
+// memory.
+func (c *Cache) find(key string) int32 {
+	h := hashKey(key)
+	tag := uint32(h)
+	mask := len(c.index.slots) - 1
+	for p := c.index.home(h); ; p = (p + 1) & mask {
+		s := &c.index.slots[p]
+		if s.entry == nilIndex {
+			return nilIndex
+		}
+		if s.tag != tag {
+			continue
+		}
+		// the data runs on past the key, so check the entry's key length
+		// first
+		if int(c.entries[s.entry].keyLen) == len(key) && string(c.slabs.data(s.ref)[:len(key)]) == key {
+			return s.entry
+		}
+	}
+}
+
+// indexEntry adds the entry to the index, growing the index first if it's
+// getting full. The entry must not be in the LRU list yet.
+func (c *Cache) indexEntry(i int32) {
+	if (c.index.count+1)*4 > len(c.index.slots)*indexLoad {
+		c.growIndex()
+	}
+	c.placeEntry(i)
+}
+
+// placeEntry puts the entry in the first free slot from its home.
+func (c *Cache) placeEntry(i int32) {
+	e := &c.entries[i]
+	mask := len(c.index.slots) - 1
+	p := c.index.home(e.hash)
+	for c.index.slots[p].entry != nilIndex {
+		p = (p + 1) & mask
+	}
+	c.index.slots[p] = indexSlot{ref: e.ref, tag: uint32(e.hash), entry: i}
+	c.index.count++
+}
+
+// growIndex doubles the number of slots, placing every entry in the LRU list
+// again.
+func (c *Cache) growIndex() {
+	c.index = newKeyIndex(len(c.index.slots) * 2)
+	for i := c.lruList.head; i != nilIndex; i = c.entries[i].next {
+		c.placeEntry(i)
+	}
+}
+
+// slotOf returns the number of the entry's slot.
+func (c *Cache) slotOf(i int32) int {
+	mask := len(c.index.slots) - 1
+	p := c.index.home(c.entries[i].hash)
+	for c.index.slots[p].entry != i {
+		p = (p + 1) & mask
+	}
+	return p
+}
+
+// reindexRef updates the index after the entry has moved to a new chunk.
+func (c *Cache) reindexRef(i int32) {
+	c.index.slots[c.slotOf(i)].ref = c.entries[i].ref
+}
+
+// unindexEntry removes the entry from the index. Rather than leaving a marker
+// in its slot, the entries after it in the same run of slots are shifted back
+// to fill the gap, where that doesn't move them before their home slot, so
+// lookups can still stop at the first empty slot.
+func (c *Cache) unindexEntry(i int32) {
+	slots := c.index.slots
+	mask := len(slots) - 1
+	c.index.count--
+	p := c.slotOf(i)
+	for {
+		slots[p].entry = nilIndex
+		q := p
+		for {
+			q = (q + 1) & mask
+			if slots[q].entry == nilIndex {
+				return
+			}
+			// the entry at q can move back to p unless its home is
+			// (cyclically) after p
+			home := c.index.home(c.entries[slots[q].entry].hash)
+			if (q-home)&mask >= (q-p)&mask {
+				slots[p] = slots[q]
+				p = q
+				break
+			}
+		}
+	}
+}
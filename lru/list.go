@@ -0,0 +1,188 @@
+package lru
+
+import "time"
+
+// list is a list value. Elements are kept in order in a slice, which makes
+// pushes and pops on the right cheap and on the left proportional to the
+// length, fine for the short lists a cache is meant for.
+type list struct {
+	elements [][]byte
+	bytes    int
+}
+
+func (l *list) size() int {
+	return l.bytes
+}
+
+// getList returns the list stored at key and its entry index. With create, a
+// missing key gets a new, empty list. A key holding something other than a
+// list is ErrWrongType.
+func (c *Cache) getList(key string, create bool) (*list, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !create {
+			return nil, nilIndex, ErrNotFound
+		}
+		l := &list{}
+		return l, c.insertObject(key, l), nil
+	}
+	l, ok := c.objects[i].(*list)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return l, i, nil
+}
+
+// LPush inserts values at the head of the list stored at key, creating the
+// list if it doesn't exist, and returns the new length. Values are inserted
+// one after the other, so the last one ends up first. If ttl is not zero,
+// the TTL of the whole list is set to it.
+func (c *Cache) LPush(key string, values [][]byte, ttl time.Duration) (int, error) {
+	return c.push(key, values, ttl, true)
+}
+
+// RPush appends values to the tail of the list stored at key, creating the
+// list if it doesn't exist, and returns the new length. If ttl is not zero,
+// the TTL of the whole list is set to it.
+func (c *Cache) RPush(key string, values [][]byte, ttl time.Duration) (int, error) {
+	return c.push(key, values, ttl, false)
+}
+
+func (c *Cache) push(key string, values [][]byte, ttl time.Duration, left bool) (int, error) {
+	if len(values) == 0 {
+		return c.LLen(key)
+	}
+	l, i, err := c.getList(key, true)
+	if err != nil {
+		return 0, err
+	}
+	copied := make([][]byte, len(values))
+	for j, value := range values {
+		copied[j] = append([]byte(nil), value...)
+		l.bytes += len(value) + elementOverhead
+	}
+	if left {
+		for j, k := 0, len(copied)-1; j < k; j, k = j+1, k-1 {
+			copied[j], copied[k] = copied[k], copied[j]
+		}
+		l.elements = append(copied, l.elements...)
+	} else {
+		l.elements = append(l.elements, copied...)
+	}
+	c.valueUpdated(i, ttl)
+	return len(l.elements), nil
+}
+
+// LPop removes and returns the first element of the list stored at key. A
+// missing key is ErrNotFound. A list left empty is deleted.
+func (c *Cache) LPop(key string) ([]byte, error) {
+	return c.pop(key, true)
+}
+
+// RPop removes and returns the last element of the list stored at key. A
+// missing key is ErrNotFound. A list left empty is deleted.
+func (c *Cache) RPop(key string) ([]byte, error) {
+	return c.pop(key, false)
+}
+
+func (c *Cache) pop(key string, left bool) ([]byte, error) {
+	l, i, err := c.getList(key, false)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	n := len(l.elements)
+	if left {
+		value = l.elements[0]
+		l.elements[0] = nil
+		l.elements = l.elements[1:]
+	} else {
+		value = l.elements[n-1]
+		l.elements[n-1] = nil
+		l.elements = l.elements[:n-1]
+	}
+	l.bytes -= len(value) + elementOverhead
+	if len(l.elements) == 0 {
+		c.removeEntry(i)
+	} else {
+		c.valueUpdated(i, 0)
+	}
+	return value, nil
+}
+
+// LRange returns the elements of the list stored at key from start to stop,
+// inclusive. Negative indexes count from the end of the list, so -1 is the
+// last element. Out of range indexes are clamped, and a missing key is an
+// empty list.
+func (c *Cache) LRange(key string, start, stop int) ([][]byte, error) {
+	l, _, err := c.getList(key, false)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	start, stop = listRange(len(l.elements), start, stop)
+	values := make([][]byte, 0, stop-start)
+	for _, value := range l.elements[start:stop] {
+		values = append(values, append([]byte(nil), value...))
+	}
+	return values, nil
+}
+
+// LTrim trims the list stored at key to the elements from start to stop,
+// inclusive, with indexes as in LRange. A list left empty is deleted.
+func (c *Cache) LTrim(key string, start, stop int) error {
+	l, i, err := c.getList(key, false)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	start, stop = listRange(len(l.elements), start, stop)
+	if start == stop {
+		c.removeEntry(i)
+		return nil
+	}
+	l.elements = append([][]byte(nil), l.elements[start:stop]...)
+	l.bytes = 0
+	for _, value := range l.elements {
+		l.bytes += len(value) + elementOverhead
+	}
+	c.valueUpdated(i, 0)
+	return nil
+}
+
+// LLen returns the length of the list stored at key, or 0 if it doesn't exist.
+func (c *Cache) LLen(key string) (int, error) {
+	l, _, err := c.getList(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(l.elements), nil
+}
+
+// listRange converts inclusive, possibly negative, start and stop indexes into
+// a slice range within [0, n].
+func listRange(n, start, stop int) (int, int) {
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	if start > stop {
+		return 0, 0
+	}
+	return start, stop + 1
+}
@@ -0,0 +1,106 @@
+package lru
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// A lock is stored as a structured value holding the fencing token and the
+// owner token. Its TTL is the lease, so a lock whose owner goes away is
+// released when it expires. Being structured, a lock can't be read with Get
+// (which would give away the owner token) or changed with the plain value
+// functions; the ones that would otherwise replace or remove any value, like
+// Set and Delete, return ErrWrongType for a lock instead, so only its owner
+// can release it. For the same reason a held lock is never evicted via LRU to
+// make room under maxEntries or maxBytes, which would let another owner
+// acquire it while the first still thinks it holds it: it only goes away when
+// its lease runs out or it's released. (The functions aren't called Lock and
+// Unlock as those belong to the Cache's embedded sync.RWMutex.)
+type lock struct {
+	fence uint64
+	owner string
+}
+
+func (l *lock) size() int {
+	return 8 + len(l.owner)
+}
+
+// isLock returns true if the entry holds a lock that hasn't expired.
+func (c *Cache) isLock(i int32) bool {
+	if !c.isObject(i) {
+		return false
+	}
+	_, ok := c.objects[i].(*lock)
+	return ok && !isExpired(&c.entries[i])
+}
+
+// getLock returns the lock stored at key and its entry index. A key holding
+// something other than a lock is ErrWrongType.
+func (c *Cache) getLock(key string) (*lock, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		return nil, nilIndex, ErrNotFound
+	}
+	l, ok := c.objects[i].(*lock)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return l, i, nil
+}
+
+// AcquireLock acquires the lock at key for ttl, which must be greater than 0.
+// It returns a random owner token, needed to release or renew the lock, and a
+// fencing token. Fencing tokens come from the same counter as CAS IDs, so
+// each acquisition gets a larger one than the last, which lets the resources
+// the lock protects reject writes from an owner whose lease has run out. If
+// the key already exists, locked or not, it returns ErrExists. The lock
+// counts against maxEntries and maxBytes but isn't evicted to stay within
+// them.
+func (c *Cache) AcquireLock(key string, ttl time.Duration) (string, uint64, error) {
+	if ttl <= 0 {
+		return "", 0, ErrInvalid
+	}
+	if c.getElement(key) != nilIndex {
+		return "", 0, ErrExists
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", 0, err
+	}
+	l := &lock{fence: c.nextCasID(), owner: hex.EncodeToString(token)}
+	c.valueUpdated(c.insertObject(key, l), ttl)
+	return l.owner, l.fence, nil
+}
+
+// ReleaseLock releases the lock at key if owner holds it. A missing (or
+// expired) lock is ErrNotFound, and one held by another owner is ErrNotOwner.
+func (c *Cache) ReleaseLock(key string, owner string) error {
+	l, i, err := c.getLock(key)
+	if err != nil {
+		return err
+	}
+	if l.owner != owner {
+		return ErrNotOwner
+	}
+	c.removeEntry(i)
+	return nil
+}
+
+// RenewLock extends the lease on the lock at key to ttl from now if owner
+// holds it, returning its fencing token, which doesn't change. Errors are as
+// for ReleaseLock.
+func (c *Cache) RenewLock(key string, owner string, ttl time.Duration) (uint64, error) {
+	if ttl <= 0 {
+		return 0, ErrInvalid
+	}
+	l, i, err := c.getLock(key)
+	if err != nil {
+		return 0, err
+	}
+	if l.owner != owner {
+		return 0, ErrNotOwner
+	}
+	c.valueUpdated(i, ttl)
+	return l.fence, nil
+}
@@ -35,7 +35,7 @@ set of operations. In addition, it allows the caller to use their preferred
 method for assuring safe concurrent access. And finally, if the library is used
 in a single-threaded situation, locking unecessary locking won't needlessly
 impact performance. All that said, the Cache type conveniently embeds
-sync.Mutex so that a per instance lock is easy to use:
+sync.RWMutex so that a per instance lock is easy to use:
 
 		myCache.Lock()
 		myCache.Set("thing", []byte("stuff"), 0)
@@ -43,14 +43,53 @@ sync.Mutex so that a per instance lock is easy to use:
 
 Note also that all operations read from and potentially write to internal data
 structures, so locking in a concurrent environment is necessary, even for
-Get/Gets.
+Get/Gets. The exceptions are Peek and Inspect, which only read, so they can be
+called under a read lock concurrently with each other:
+
+		myCache.RLock()
+		value, cas, err := myCache.Peek("thing")
+		myCache.RUnlock()
+
+Items evicted via LRU are normally gone for good. A second tier (for example
+the on-disk store in the disk package) can be attached to catch them:
+
+		myCache := lru.New(1000).WithTier(store)
+
+Items evicted via LRU are then handed to the Tier, and a key that misses in
+memory is looked up in the Tier and, if found, promoted back into memory.
+
+Internally, keys and values are copied into large slab pages split into
+chunks by size class (much like memcached's slab allocator), and items are
+linked into the LRU and the key index by slice index rather than by pointer.
+This keeps the number of pointers the garbage collector has to scan
+independent of the number of cached items. A consequence is that values
+passed in are copied, so callers are free to modify them. Values handed out
+are copies too, except from Get and Gets: to keep reads from allocating,
+those return the cache's own memory, which mustn't be modified and is only
+good until the next call that changes the cache, when its chunk may be
+reused. A caller that keeps the value longer (past releasing the lock, say)
+has to copy it.
+
+Besides plain []byte values, an item can hold a structured value, such as a
+hash (see HSet), operated on in place by its own set of functions. A
+structured value is still a single item with a single TTL as far as LRU and
+expiration go, and counts an estimate of its size against WithMaxBytes.
+Using a plain value function on a structured value (or vice versa) returns
+ErrWrongType, except for Set and friends which replace whatever was there.
+Structured values are kept outside of the slabs and aren't handed to the
+second tier on eviction.
+
+HyperLogLogs (see PFAdd) and Bloom filters (see BFAdd) are different: they're
+plain values in a compact encoding that their functions recognize and update
+in place. So they can be read with Get and written back with Set, say to
+save and restore them, and they're handed to the second tier like any other
+value.
 
 */
 package lru
 
 import (
 	"bytes"
-	"container/list"
 	"encoding/binary"
 	"errors"
 	"sync"
@@ -58,25 +97,80 @@ import (
 	"time"
 )
 
+// nilIndex marks the end of a list or hash chain.
+const nilIndex = -1
+
 // Cache is an LRU+TTL cache
 type Cache struct {
-	sync.Mutex
+	sync.RWMutex
 	maxEntries      int
+	maxBytes        int64
+	bytes           int64
 	evictionHandler EvictionHandler
-	lruList         *list.List
-	cache           map[string]*list.Element
-	casID           uint64
+	tier            Tier
+	lruList         lruList
+	index           keyIndex
+	entries         []entry
+	freeEntries     []int32
+	slabs           slabs
+	// objects holds structured values (hashes, etc.) by entry index. An
+	// entry with an object has no value in its slab chunk, just the key.
+	objects map[int32]object
+	casID   uint64
 }
 
-// entry represents a an entry in the cache.
+// object is a structured value.
+type object interface {
+	// size returns roughly how many bytes the value takes up, for eviction
+	// by size. It must be cheap to call.
+	size() int
+}
+
+// elementOverhead is a rough per element cost (map entry, slice header, etc.)
+// added to the size of structured values.
+const elementOverhead = 16
+
+// lruList is a doubly linked list of entries, most recently used first,
+// linked by index into Cache.entries.
+type lruList struct {
+	head int32
+	tail int32
+	len  int
+}
+
+// entry represents a an entry in the cache. The key and value live in the
+// entry's slab chunk, key first. entry deliberately holds no pointers.
 type entry struct {
-	key       string
-	value     []byte
-	cas       uint64
-	ttl       time.Duration
-	createdAt time.Time
+	// the fields a read touches come first, to share a cache line
+	hash     uint64
+	ref      slabRef
+	keyLen   uint32
+	valueLen uint32
+	flags    uint8
+	prev     int32
+	next     int32
+	ttl      time.Duration
+	// accessedAt is only accurate to the second (see coarseNow)
+	accessedAt int64
+	cas        uint64
+	createdAt  int64
+	// size is the number of bytes the entry is accounted for in Cache.bytes
+	size uint32
 }
 
+const (
+	// entryFetched is set once an item has been read.
+	entryFetched = 1 << iota
+	// entryStale is set when an item has been invalidated (see MetaDelete).
+	entryStale
+	// entryWinSent is set once a client has been told it won the right to
+	// recache an item (see MetaGet).
+	entryWinSent
+	// entryObject is set while the entry holds a structured value, so reads
+	// of plain values can tell without looking it up in Cache.objects.
+	entryObject
+)
+
 // EvictionReason encapsulates the reason for an eviction in an evictionHandler
 type EvictionReason int
 
@@ -113,18 +207,74 @@ func (h EvictionHandlerFunc) HandleEviction(key string, value []byte, reason Evi
 	h(key, value, reason)
 }
 
+// Tier is a second level store for items evicted from the cache via LRU. Put
+// is called with an item as it is evicted, and Get is called when a key isn't
+// found in memory. An item returned by Get is promoted back into memory, after
+// which the cache calls Delete so the Tier doesn't keep a stale copy. Any
+// error returned from Get is treated as a miss; errors returned from Put mean
+// the evicted item is lost, just as it would be without a Tier.
+type Tier interface {
+	Put(key string, value []byte, cas uint64, expiresAt time.Time) error
+	Get(key string) (value []byte, cas uint64, expiresAt time.Time, err error)
+	Delete(key string) error
+	Flush() error
+}
+
+// ItemInfo is metadata about a cached item, as returned by Inspect.
+type ItemInfo struct {
+	// Age is the time since the item was last set (or touched).
+	Age time.Duration
+	// TTL is the time remaining until the item expires, or 0 if it doesn't.
+	TTL time.Duration
+	// Size is the size of the value in bytes, or an estimate of it for
+	// structured values.
+	Size int
+	// CAS is the item's current CAS ID.
+	CAS uint64
+	// LastAccess is the time since the item was last read or written. Reads
+	// are only timed to the second (see coarseNow).
+	LastAccess time.Duration
+	// Tiered is true if the item is currently in the second tier rather than
+	// in memory. Age and LastAccess aren't tracked for tiered items.
+	Tiered bool
+}
+
 // ErrNotFound is the error returned when a value isn't found.
 var ErrNotFound = errors.New("item not found")
 
 // ErrExists is the error returned when an item exists.
 var ErrExists = errors.New("item exists")
 
+// ErrWrongType is the error returned when an operation is used on the wrong
+// kind of value, for example a hash operation on a plain value or Get on a
+// hash.
+var ErrWrongType = errors.New("wrong kind of value")
+
+// ErrNotInteger is the error returned when an integer operation is used on a
+// value that isn't an integer.
+var ErrNotInteger = errors.New("value is not an integer")
+
+// ErrOverflow is the error returned when adding to an integer would take it
+// past the range it's stored in.
+var ErrOverflow = errors.New("increment or decrement would overflow")
+
+// ErrInvalid is the error returned when an operation is given an invalid
+// argument, such as a Bloom filter error rate outside of (0, 1).
+var ErrInvalid = errors.New("invalid argument")
+
+// ErrNotOwner is the error returned when unlocking or renewing a lock with an
+// owner token other than the one it was acquired with.
+var ErrNotOwner = errors.New("lock held by another owner")
+
 // New creates a new Cache and initializes the various internal items.
 func New(maxEntries int) *Cache {
+	startCoarseClock()
 	return &Cache{
 		maxEntries: maxEntries,
-		lruList:    list.New(),
-		cache:      make(map[string]*list.Element),
+		lruList:    lruList{head: nilIndex, tail: nilIndex},
+		index:      newKeyIndex(minIndexSlots),
+		slabs:      newSlabs(),
+		objects:    make(map[int32]object),
 		casID:      0,
 	}
 }
@@ -137,65 +287,183 @@ func (c *Cache) WithEvictionHandler(h EvictionHandler) *Cache {
 	return c
 }
 
+// WithMaxBytes limits the total size of the cache's keys and values. Items
+// are evicted via LRU to stay within it, as with maxEntries, except that an
+// item too large for the limit on its own is kept until something else is
+// added. Structured values count an estimate of their size, and held locks
+// count but are never evicted (see AcquireLock). Set it to 0 for unlimited,
+// the default.
+func (c *Cache) WithMaxBytes(n int64) *Cache {
+	c.maxBytes = n
+	c.evictOverflow()
+	return c
+}
+
+// WithTier attaches a second level store that receives items evicted via LRU
+// and serves misses. See Tier for details.
+func (c *Cache) WithTier(t Tier) *Cache {
+	c.tier = t
+	return c
+}
+
+// Len returns the number of items in memory, including any that have expired
+// but haven't been evicted yet.
+func (c *Cache) Len() int {
+	return c.lruList.len
+}
+
+// Bytes returns the total size of the items in memory, as counted against the
+// WithMaxBytes limit.
+func (c *Cache) Bytes() int64 {
+	return c.bytes
+}
+
 // Set unconditionally sets the item, potentially overwriting a previous value
-// and moving the item to the top of the LRU.
-func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+// and moving the item to the top of the LRU. It returns the item's new CAS ID.
+// The one thing it won't overwrite is a lock, which is ErrWrongType.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) (uint64, error) {
 	// key already exists, update values and move to the front
-	if ee, ok := c.cache[key]; ok {
-		c.lruList.MoveToFront(ee)
-		ee.Value.(*entry).value = value
-		ee.Value.(*entry).ttl = ttl
-		ee.Value.(*entry).createdAt = time.Now()
-		ee.Value.(*entry).cas = c.nextCasID()
-		return
+	if i := c.find(key); i != nilIndex {
+		if c.isLock(i) {
+			return 0, ErrWrongType
+		}
+		cas := c.nextCasID()
+		c.moveToFront(i)
+		c.setValue(i, value)
+		e := &c.entries[i]
+		e.ttl = ttl
+		e.createdAt = time.Now().UnixNano()
+		e.accessedAt = e.createdAt
+		e.cas = cas
+		c.evictOverflow()
+		return cas, nil
 	}
 	// new entry: create, store and update the LRU
-	ele := c.lruList.PushFront(&entry{
-		key:       key,
-		value:     value,
-		ttl:       ttl,
-		createdAt: time.Now(),
-		cas:       c.nextCasID(),
-	})
-	c.cache[key] = ele
-	if c.maxEntries != 0 && c.lruList.Len() > c.maxEntries {
-		ele := c.lruList.Back()
-		if ele != nil {
-			if c.evictionHandler != nil {
-				c.evictionHandler.HandleEviction(ele.Value.(*entry).key, ele.Value.(*entry).value, LRUEviction)
+	cas := c.nextCasID()
+	c.insert(key, value, ttl, cas)
+	c.evictOverflow()
+	return cas, nil
+}
+
+// evictOverflow evicts items from the back of the LRU until the cache is
+// within maxEntries and maxBytes. Held locks are passed over, so if only locks
+// are left the cache stays over its limits.
+func (c *Cache) evictOverflow() {
+	for c.overflowing() {
+		i := c.lruList.tail
+		for i != nilIndex && c.isLock(i) {
+			i = c.entries[i].prev
+		}
+		if i == nilIndex {
+			return
+		}
+		c.evict(i, LRUEviction)
+	}
+}
+
+// overflowing returns true if the cache is over maxEntries or maxBytes.
+func (c *Cache) overflowing() bool {
+	if c.maxEntries != 0 && c.lruList.len > c.maxEntries {
+		return true
+	}
+	return c.maxBytes != 0 && c.bytes > c.maxBytes && c.lruList.len > 1
+}
+
+// evict removes the entry from the cache, calling the eviction handler and,
+// for LRU evictions, spilling the item to the second tier if there is one.
+func (c *Cache) evict(i int32, reason EvictionReason) {
+	e := &c.entries[i]
+	if c.evictionHandler != nil || (reason == LRUEviction && c.tier != nil) {
+		key := string(c.key(e))
+		value := c.copyValue(e)
+		if c.evictionHandler != nil {
+			c.evictionHandler.HandleEviction(key, value, reason)
+		}
+		// structured values can't be stored in the tier
+		if _, ok := c.objects[i]; !ok && reason == LRUEviction && c.tier != nil {
+			var expiresAt time.Time
+			if e.ttl != 0 {
+				expiresAt = time.Unix(0, e.createdAt).Add(e.ttl)
 			}
-			c.removeElement(ele)
+			c.tier.Put(key, value, e.cas, expiresAt)
 		}
 	}
+	c.removeEntry(i)
+}
+
+// lookup returns the index of the entry for key without touching the LRU or
+// checking expiration. On a miss it falls back to the second tier, promoting
+// the item back into memory if the tier has it.
+func (c *Cache) lookup(key string) int32 {
+	if i := c.find(key); i != nilIndex {
+		return i
+	}
+	return c.promote(key)
+}
+
+// promote moves the item for key from the second tier into memory, if it's
+// there.
+func (c *Cache) promote(key string) int32 {
+	if c.tier == nil {
+		return nilIndex
+	}
+	value, cas, expiresAt, err := c.tier.Get(key)
+	if err != nil {
+		return nilIndex
+	}
+	var ttl time.Duration
+	if !expiresAt.IsZero() {
+		ttl = expiresAt.Sub(time.Now())
+		if ttl <= 0 {
+			c.tier.Delete(key)
+			return nilIndex
+		}
+	}
+	// insert removes it from the tier
+	i := c.insert(key, value, ttl, cas)
+	c.evictOverflow()
+	return i
 }
 
 // Touch updates the item's eviction status (LRU and TTL if supplied) and CAS
 // ID without requiring the value. If the item doesn't already exist, it
-// returns an ErrNotFound.
-func (c *Cache) Touch(key string, ttl time.Duration) error {
-	if ele, ok := c.cache[key]; ok {
-		c.Set(key, ele.Value.(*entry).value, ttl)
-		return nil
+// returns an ErrNotFound. If cas is not 0, the item is only touched if its
+// CAS ID matches, otherwise it returns ErrExists. It returns the item's new
+// CAS ID.
+func (c *Cache) Touch(key string, ttl time.Duration, cas uint64) (uint64, error) {
+	if i := c.lookup(key); i != nilIndex {
+		if c.isLock(i) {
+			return 0, ErrWrongType
+		}
+		if cas != 0 && c.entries[i].cas != cas {
+			return 0, ErrExists
+		}
+		c.moveToFront(i)
+		e := &c.entries[i]
+		e.ttl = ttl
+		e.createdAt = time.Now().UnixNano()
+		e.accessedAt = e.createdAt
+		e.cas = c.nextCasID()
+		return e.cas, nil
 	}
-	return ErrNotFound
+	return 0, ErrNotFound
 }
 
-// Add sets the item only if it doesn't already exist.
-func (c *Cache) Add(key string, value []byte, ttl time.Duration) error {
-	if _, ok := c.cache[key]; !ok {
-		c.Set(key, value, ttl)
-		return nil
+// Add sets the item only if it doesn't already exist, returning its CAS ID.
+func (c *Cache) Add(key string, value []byte, ttl time.Duration) (uint64, error) {
+	if i := c.lookup(key); i == nilIndex {
+		return c.Set(key, value, ttl)
 	}
-	return ErrExists
+	return 0, ErrExists
 }
 
-// Replace only sets the item if it does already exist.
-func (c *Cache) Replace(key string, value []byte, ttl time.Duration) error {
-	if _, ok := c.cache[key]; ok {
-		c.Set(key, value, ttl)
-		return nil
+// Replace only sets the item if it does already exist, returning its new CAS
+// ID.
+func (c *Cache) Replace(key string, value []byte, ttl time.Duration) (uint64, error) {
+	if i := c.lookup(key); i != nilIndex {
+		return c.Set(key, value, ttl)
 	}
-	return ErrNotFound
+	return 0, ErrNotFound
 }
 
 // Cas is a "compare and swap" or "check and set" operation. It attempts to set
@@ -204,76 +472,217 @@ func (c *Cache) Replace(key string, value []byte, ttl time.Duration) error {
 // it returns ErrNotFound, and if the item exists but has a different cas
 // value, it returns ErrExists. CAS is useful when multiple clients may be
 // operating on the same cached items and updates should only be applied by one
-// if a change hasn't occurred in the meantime by another.
-func (c *Cache) Cas(key string, value []byte, ttl time.Duration, cas uint64) error {
-	if ele, ok := c.cache[key]; ok {
-		if ele.Value.(*entry).cas == cas {
-			c.Set(key, value, ttl)
-			return nil
-		}
-		return ErrExists
-	}
-	return ErrNotFound
-}
-
-// getElement gets the raw cache entry from the map if it both exists and has
-// not yet expired. If the element is still valid, it moves it to the front of
-// the LRU list and returns the raw element.
-func (c *Cache) getElement(key string) *list.Element {
-	if ele, hit := c.cache[key]; hit {
-		if isExpired(ele) {
-			if c.evictionHandler != nil {
-				c.evictionHandler.HandleEviction(ele.Value.(*entry).key, ele.Value.(*entry).value, TTLEviction)
+// if a change hasn't occurred in the meantime by another. On success it
+// returns the item's new CAS ID.
+func (c *Cache) Cas(key string, value []byte, ttl time.Duration, cas uint64) (uint64, error) {
+	if i := c.lookup(key); i != nilIndex {
+		if c.entries[i].cas == cas {
+			return c.Set(key, value, ttl)
+		}
+		return 0, ErrExists
+	}
+	return 0, ErrNotFound
+}
+
+// getElement gets the index of the cache entry (from memory or the second
+// tier) if it both exists and has not yet expired. If the entry is still
+// valid, it moves it to the front of the LRU list and returns its index,
+// otherwise nilIndex.
+func (c *Cache) getElement(key string) int32 {
+	// lookup, unrolled so a read of an item in memory makes one less call
+	i := c.find(key)
+	if i == nilIndex {
+		i = c.promote(key)
+	}
+	if i != nilIndex {
+		if isExpired(&c.entries[i]) {
+			c.evict(i, TTLEviction)
+			return nilIndex
+		}
+		c.moveToFront(i)
+		e := &c.entries[i]
+		// the coarse time can be behind the exact one a write recorded
+		if now := coarseNow(); now > e.accessedAt {
+			e.accessedAt = now
+		}
+		e.flags |= entryFetched
+		return i
+	}
+	return nilIndex
+}
+
+// Inspect returns metadata about the item without otherwise touching it: the
+// item isn't moved in the LRU, its CAS and access time aren't updated, expired
+// items aren't evicted (they're just reported as ErrNotFound) and items in the
+// second tier aren't promoted.
+func (c *Cache) Inspect(key string) (ItemInfo, error) {
+	if i := c.find(key); i != nilIndex {
+		e := &c.entries[i]
+		if isExpired(e) {
+			return ItemInfo{}, ErrNotFound
+		}
+		now := time.Now().UnixNano()
+		info := ItemInfo{
+			Age:        time.Duration(now - e.createdAt),
+			Size:       int(e.size - e.keyLen),
+			CAS:        e.cas,
+			LastAccess: time.Duration(now - e.accessedAt),
+		}
+		if e.ttl != 0 {
+			info.TTL = e.ttl - info.Age
+		}
+		return info, nil
+	}
+	if c.tier != nil {
+		value, cas, expiresAt, err := c.tier.Get(key)
+		if err != nil {
+			return ItemInfo{}, ErrNotFound
+		}
+		info := ItemInfo{Size: len(value), CAS: cas, Tiered: true}
+		if !expiresAt.IsZero() {
+			info.TTL = expiresAt.Sub(time.Now())
+			if info.TTL <= 0 {
+				return ItemInfo{}, ErrNotFound
 			}
-			c.removeElement(ele)
-			return nil
 		}
-		c.lruList.MoveToFront(ele)
-		return ele
+		return info, nil
 	}
-	return nil
+	return ItemInfo{}, ErrNotFound
 }
 
-// Get gets the value for the given key.
+// Peek gets the value and CAS ID for the given key without moving the item in
+// the LRU or updating its access time. Like Inspect, it doesn't modify the
+// cache at all, so it's safe to call under a read lock: expired items are
+// reported as ErrNotFound but not evicted, and items found in the second tier
+// are returned without being promoted.
+func (c *Cache) Peek(key string) ([]byte, uint64, error) {
+	if i := c.find(key); i != nilIndex {
+		e := &c.entries[i]
+		if isExpired(e) {
+			return nil, 0, ErrNotFound
+		}
+		if c.isObject(i) {
+			return nil, 0, ErrWrongType
+		}
+		return c.copyValue(e), e.cas, nil
+	}
+	if c.tier != nil {
+		value, cas, expiresAt, err := c.tier.Get(key)
+		if err != nil || (!expiresAt.IsZero() && !time.Now().Before(expiresAt)) {
+			return nil, 0, ErrNotFound
+		}
+		return value, cas, nil
+	}
+	return nil, 0, ErrNotFound
+}
+
+// Get gets the value for the given key. The value isn't a copy: it mustn't be
+// modified, and is only good until the cache is next changed.
 func (c *Cache) Get(key string) ([]byte, error) {
-	ele := c.getElement(key)
-	if ele != nil {
-		return ele.Value.(*entry).value, nil
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return nil, ErrWrongType
+		}
+		return c.value(&c.entries[i]), nil
 	}
 	return nil, ErrNotFound
 }
 
 // Gets gets the value for the given key and also returns the value's CAS ID.
+// As with Get, the value isn't a copy.
 func (c *Cache) Gets(key string) ([]byte, uint64, error) {
-	ele := c.getElement(key)
-	if ele != nil {
-		return ele.Value.(*entry).value, ele.Value.(*entry).cas, nil
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return nil, 0, ErrWrongType
+		}
+		return c.value(&c.entries[i]), c.entries[i].cas, nil
 	}
 	return nil, 0, ErrNotFound
 }
 
 // Append appends the given value to the currently stored value for the key. If
 // the key doesn't currently exist (or has aged out) ErrNotFound is returned.
-func (c *Cache) Append(key string, value []byte, ttl time.Duration) error {
-	ele := c.getElement(key)
-	if ele != nil {
-		newValue := append(ele.Value.(*entry).value, value...)
-		c.Set(key, newValue, ttl)
-		return nil
+// If cas is not 0, the value is only appended to if its CAS ID matches,
+// otherwise ErrExists is returned. It returns the item's new CAS ID.
+func (c *Cache) Append(key string, value []byte, ttl time.Duration, cas uint64) (uint64, error) {
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return 0, ErrWrongType
+		}
+		if cas != 0 && c.entries[i].cas != cas {
+			return 0, ErrExists
+		}
+		newValue := append(c.copyValue(&c.entries[i]), value...)
+		return c.Set(key, newValue, ttl)
 	}
-	return ErrNotFound
+	return 0, ErrNotFound
 }
 
 // Prepend prepends the given value to the currently stored value for the key. If
 // the key doesn't currently exist (or has aged out) ErrNotFound is returned.
-func (c *Cache) Prepend(key string, value []byte, ttl time.Duration) error {
-	ele := c.getElement(key)
-	if ele != nil {
-		newValue := append(value, ele.Value.(*entry).value...)
-		c.Set(key, newValue, ttl)
-		return nil
+// The cas argument and result are as for Append.
+func (c *Cache) Prepend(key string, value []byte, ttl time.Duration, cas uint64) (uint64, error) {
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return 0, ErrWrongType
+		}
+		if cas != 0 && c.entries[i].cas != cas {
+			return 0, ErrExists
+		}
+		newValue := append(append([]byte{}, value...), c.value(&c.entries[i])...)
+		return c.Set(key, newValue, ttl)
 	}
-	return ErrNotFound
+	return 0, ErrNotFound
+}
+
+// GetSet sets the item like Set and returns its previous value, true if it
+// had one, and its new CAS ID. A key holding a structured value is
+// ErrWrongType.
+func (c *Cache) GetSet(key string, value []byte, ttl time.Duration) ([]byte, bool, uint64, error) {
+	var old []byte
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return nil, false, 0, ErrWrongType
+		}
+		old = c.copyValue(&c.entries[i])
+	}
+	cas, err := c.Set(key, value, ttl)
+	return old, i != nilIndex, cas, err
+}
+
+// SetNX sets the item only if it doesn't already exist, like Add, but rather
+// than failing when it does, it returns the existing value and true. The CAS
+// ID returned is that of the stored item, whether it's new or existing.
+func (c *Cache) SetNX(key string, value []byte, ttl time.Duration) ([]byte, bool, uint64, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		cas, err := c.Set(key, value, ttl)
+		return nil, false, cas, err
+	}
+	if c.isObject(i) {
+		return nil, false, 0, ErrWrongType
+	}
+	return c.copyValue(&c.entries[i]), true, c.entries[i].cas, nil
+}
+
+// GetDel deletes the item and returns the value and CAS ID it had. If the key
+// doesn't currently exist (or has aged out) ErrNotFound is returned.
+func (c *Cache) GetDel(key string) ([]byte, uint64, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		return nil, 0, ErrNotFound
+	}
+	if c.isObject(i) {
+		return nil, 0, ErrWrongType
+	}
+	value, cas := c.copyValue(&c.entries[i]), c.entries[i].cas
+	c.removeEntry(i)
+	return value, cas, nil
 }
 
 // BytesToUint64 is a helper to convert a byte slice to a uint64.
@@ -294,84 +703,333 @@ func Uint64ToBytes(n uint64) []byte {
 
 // Increment increments the value of key by incrementBy. The value should be stored
 // as a uint64 converted to a []byte with Uint64ToBytes (or something
-// equivalent) or the behavior is undefined.
-func (c *Cache) Increment(key string, incrementBy uint64) error {
-	ele := c.getElement(key)
-	if ele != nil {
-		n, err := BytesToUint64(ele.Value.(*entry).value)
+// equivalent) or the behavior is undefined. It returns the new value and CAS
+// ID.
+func (c *Cache) Increment(key string, incrementBy uint64) (uint64, uint64, error) {
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return 0, 0, ErrWrongType
+		}
+		n, err := BytesToUint64(c.value(&c.entries[i]))
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 
 		n += incrementBy
 
-		b := Uint64ToBytes(n)
-		ele.Value.(*entry).value = b
-		ele.Value.(*entry).cas = c.nextCasID()
-		return nil
+		c.setValue(i, Uint64ToBytes(n))
+		cas := c.nextCasID()
+		c.entries[i].cas = cas
+		c.evictOverflow()
+		return n, cas, nil
 
 	}
-	return ErrNotFound
+	return 0, 0, ErrNotFound
 }
 
 // Decrement decrements the value of key by decrBy. The value should be stored
 // as a uint64 converted to a []byte with Uint64ToBytes (or something
-// equivalent) or the behavior is undefined.
-func (c *Cache) Decrement(key string, decrementBy uint64) error {
-	ele := c.getElement(key)
-	if ele != nil {
-		n, err := BytesToUint64(ele.Value.(*entry).value)
+// equivalent) or the behavior is undefined. It returns the new value and CAS
+// ID.
+func (c *Cache) Decrement(key string, decrementBy uint64) (uint64, uint64, error) {
+	i := c.getElement(key)
+	if i != nilIndex {
+		if c.isObject(i) {
+			return 0, 0, ErrWrongType
+		}
+		n, err := BytesToUint64(c.value(&c.entries[i]))
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 
 		n -= decrementBy
 
-		b := Uint64ToBytes(n)
-		ele.Value.(*entry).value = b
-		ele.Value.(*entry).cas = c.nextCasID()
-		return nil
+		c.setValue(i, Uint64ToBytes(n))
+		cas := c.nextCasID()
+		c.entries[i].cas = cas
+		c.evictOverflow()
+		return n, cas, nil
 
 	}
-	return ErrNotFound
+	return 0, 0, ErrNotFound
 }
 
-// Delete deletes the item from the cache.
-func (c *Cache) Delete(key string) {
-	if ele, hit := c.cache[key]; hit {
-		c.removeElement(ele)
+// Delete deletes the item from the cache (and the second tier, if any). If
+// cas is 0 the delete is unconditional and always succeeds. Otherwise the item
+// is only deleted if its CAS ID matches: a missing item is ErrNotFound and a
+// different CAS ID is ErrExists. A lock can only be deleted by releasing it,
+// so it's ErrWrongType.
+func (c *Cache) Delete(key string, cas uint64) error {
+	if i := c.find(key); i != nilIndex && c.isLock(i) {
+		return ErrWrongType
+	}
+	if cas != 0 {
+		i := c.lookup(key)
+		if i == nilIndex || isExpired(&c.entries[i]) {
+			return ErrNotFound
+		}
+		if c.entries[i].cas != cas {
+			return ErrExists
+		}
+	}
+	if i := c.find(key); i != nilIndex {
+		c.removeEntry(i)
+	}
+	if c.tier != nil {
+		c.tier.Delete(key)
 	}
+	return nil
 }
 
-// FlushAll removes all items from the cache.
+// FlushAll removes all items from the cache (and the second tier, if any).
 func (c *Cache) FlushAll() {
-	c.lruList = nil
-	c.cache = nil
+	c.lruList = lruList{head: nilIndex, tail: nilIndex}
+	c.index = newKeyIndex(minIndexSlots)
+	c.entries = nil
+	c.freeEntries = nil
+	c.slabs = newSlabs()
+	c.objects = make(map[int32]object)
+	c.bytes = 0
+	if c.tier != nil {
+		c.tier.Flush()
+	}
 }
 
 // nextCasId increments and returns the next cas id.
 func (c *Cache) nextCasID() uint64 {
-	atomic.AddUint64(&c.casID, 1)
-	return atomic.LoadUint64(&c.casID)
+	return atomic.AddUint64(&c.casID, 1)
+}
+
+// isObject returns true if the entry holds a structured value rather than a
+// plain []byte.
+func (c *Cache) isObject(i int32) bool {
+	return c.entries[i].flags&entryObject != 0
+}
+
+// insertObject stores a new structured value at the front of the LRU and
+// returns its index. The caller is responsible for making sure key isn't
+// already present.
+func (c *Cache) insertObject(key string, obj object) int32 {
+	i := c.insert(key, nil, 0, c.nextCasID())
+	c.objects[i] = obj
+	c.entries[i].flags |= entryObject
+	c.account(i)
+	c.evictOverflow()
+	return i
+}
+
+// valueUpdated bumps the CAS of a value modified in place, either a
+// structured value or an encoded one like a HyperLogLog, updates its size
+// and, if ttl is not zero, resets its TTL. As the value may have grown,
+// it can cause evictions (though not of the value itself, which is at the
+// front of the LRU).
+func (c *Cache) valueUpdated(i int32, ttl time.Duration) {
+	e := &c.entries[i]
+	e.cas = c.nextCasID()
+	if ttl != 0 {
+		e.ttl = ttl
+		e.createdAt = time.Now().UnixNano()
+	}
+	c.account(i)
+	c.evictOverflow()
+}
+
+// account updates the entry's size and the cache's total to match its current
+// key and value.
+func (c *Cache) account(i int32) {
+	e := &c.entries[i]
+	size := int64(e.keyLen) + int64(e.valueLen)
+	if e.flags&entryObject != 0 {
+		size += int64(c.objects[i].size())
+	}
+	c.bytes += size - int64(e.size)
+	e.size = uint32(size)
+}
+
+// coarseClock holds the time as of the last tick of a goroutine that updates it
+// every second, started by the first call to New.
+var coarseClock struct {
+	once sync.Once
+	now  int64
+}
+
+// startCoarseClock starts the goroutine that updates coarseClock, if it isn't
+// running yet.
+func startCoarseClock() {
+	coarseClock.once.Do(func() {
+		atomic.StoreInt64(&coarseClock.now, time.Now().UnixNano())
+		go func() {
+			for t := range time.Tick(time.Second) {
+				atomic.StoreInt64(&coarseClock.now, t.UnixNano())
+			}
+		}()
+	})
+}
+
+// coarseNow returns the current time in nanoseconds, to within a second. It's
+// used for the access times Get records, since reading it is much cheaper
+// than time.Now and LastAccess is only reported to the second anyway.
+func coarseNow() int64 {
+	return atomic.LoadInt64(&coarseClock.now)
 }
 
 // isExpired returns true if the item exists and is expired, false otherwise.
-func isExpired(e *list.Element) bool {
-	ttl := e.Value.(*entry).ttl
-	if ttl == 0 {
-		return false
+func isExpired(e *entry) bool {
+	return e.ttl != 0 && ttlElapsed(e)
+}
+
+// ttlElapsed returns true if the item's TTL has run out. It's kept out of
+// isExpired so that the check for items without a TTL can be inlined.
+func ttlElapsed(e *entry) bool {
+	return time.Now().UnixNano()-e.createdAt > int64(e.ttl)
+}
+
+// hashKey hashes the key with 64 bit FNV-1a.
+func hashKey(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
 	}
+	return h
+}
 
-	createdAt := e.Value.(*entry).createdAt
-	if time.Since(createdAt) > ttl {
-		return true
+// key returns the entry's key. The returned slice aliases the slab.
+func (c *Cache) key(e *entry) []byte {
+	return c.slabs.data(e.ref)[:e.keyLen]
+}
+
+// value returns the entry's value. The returned slice aliases the slab.
+func (c *Cache) value(e *entry) []byte {
+	return c.slabs.data(e.ref)[e.keyLen : e.keyLen+e.valueLen]
+}
+
+// copyValue returns a copy of the entry's value that is safe to hand out.
+func (c *Cache) copyValue(e *entry) []byte {
+	return append([]byte(nil), c.value(e)...)
+}
+
+// insert stores a new entry at the front of the LRU and returns its index.
+// The caller is responsible for making sure key isn't already present and for
+// evicting any overflow. Any copy of the item in the second tier is deleted,
+// as an item is only ever in one place: otherwise, once the new entry left
+// memory other than by being spilled (expiring, say, or being deleted from
+// memory only), the stale copy would be promoted in its place.
+func (c *Cache) insert(key string, value []byte, ttl time.Duration, cas uint64) int32 {
+	if c.tier != nil {
+		c.tier.Delete(key)
+	}
+	var i int32
+	if l := len(c.freeEntries); l > 0 {
+		i = c.freeEntries[l-1]
+		c.freeEntries = c.freeEntries[:l-1]
+	} else {
+		c.entries = append(c.entries, entry{})
+		i = int32(len(c.entries) - 1)
+	}
+	h := hashKey(key)
+	ref := c.slabs.alloc(len(key) + len(value))
+	chunk := c.slabs.chunk(ref)
+	copy(chunk, key)
+	copy(chunk[len(key):], value)
+
+	now := time.Now().UnixNano()
+	c.entries[i] = entry{
+		ref:        ref,
+		keyLen:     uint32(len(key)),
+		valueLen:   uint32(len(value)),
+		hash:       h,
+		cas:        cas,
+		ttl:        ttl,
+		createdAt:  now,
+		accessedAt: now,
+	}
+	c.indexEntry(i)
+	c.pushFront(i)
+	c.account(i)
+	return i
+}
+
+// setValue replaces the entry's value, moving it to a chunk of a different
+// size class if it no longer fits. A new value also clears the entry's flags
+// and replaces any structured value.
+func (c *Cache) setValue(i int32, value []byte) {
+	e := &c.entries[i]
+	if e.flags&entryObject != 0 {
+		delete(c.objects, i)
+	}
+	e.flags = 0
+	size := int(e.keyLen) + len(value)
+	if c.slabs.classFor(size) == e.ref.class() && size <= c.slabs.capacity(e.ref) {
+		copy(c.slabs.chunk(e.ref)[e.keyLen:], value)
+		e.valueLen = uint32(len(value))
+		c.account(i)
+		return
+	}
+	ref := c.slabs.alloc(size)
+	chunk := c.slabs.chunk(ref)
+	copy(chunk, c.key(e))
+	copy(chunk[e.keyLen:], value)
+	c.slabs.release(e.ref)
+	e.ref = ref
+	e.valueLen = uint32(len(value))
+	c.reindexRef(i)
+	c.account(i)
+}
+
+// removeEntry unconditionally removes the entry from the cache.
+func (c *Cache) removeEntry(i int32) {
+	e := &c.entries[i]
+	c.unlink(i)
+	c.unindexEntry(i)
+	c.slabs.release(e.ref)
+	delete(c.objects, i)
+	c.bytes -= int64(e.size)
+	*e = entry{}
+	c.freeEntries = append(c.freeEntries, i)
+}
+
+// pushFront links the entry in at the front of the LRU list.
+func (c *Cache) pushFront(i int32) {
+	e := &c.entries[i]
+	e.prev = nilIndex
+	e.next = c.lruList.head
+	if c.lruList.head != nilIndex {
+		c.entries[c.lruList.head].prev = i
+	}
+	c.lruList.head = i
+	if c.lruList.tail == nilIndex {
+		c.lruList.tail = i
+	}
+	c.lruList.len++
+}
+
+// unlink removes the entry from the LRU list.
+func (c *Cache) unlink(i int32) {
+	e := &c.entries[i]
+	if e.prev != nilIndex {
+		c.entries[e.prev].next = e.next
+	} else {
+		c.lruList.head = e.next
+	}
+	if e.next != nilIndex {
+		c.entries[e.next].prev = e.prev
+	} else {
+		c.lruList.tail = e.prev
+	}
+	c.lruList.len--
+}
+
+// moveToFront moves the entry to the front of the LRU list.
+func (c *Cache) moveToFront(i int32) {
+	if c.lruList.head != i {
+		c.relink(i)
 	}
-	return false
 }
 
-// removeElement unconditionally removed the element from the cache.
-func (c *Cache) removeElement(e *list.Element) {
-	c.lruList.Remove(e)
-	kv := e.Value.(*entry)
-	delete(c.cache, kv.key)
+// relink moves the entry from where it is in the LRU list to the front.
+func (c *Cache) relink(i int32) {
+	c.unlink(i)
+	c.pushFront(i)
 }
@@ -2,7 +2,15 @@ package lru
 
 import (
 	"bytes"
+	stdlist "container/list"
 	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -20,7 +28,7 @@ func TestBasic(t *testing.T) {
 	}
 
 	// add the same key, it shouldn't replace the old value since it already exists
-	err := c.Add("foo", []byte("notbar"), 0)
+	_, err := c.Add("foo", []byte("notbar"), 0)
 	if err != ErrExists {
 		t.Fatal("expected ErrExists")
 	}
@@ -31,7 +39,7 @@ func TestBasic(t *testing.T) {
 	}
 
 	// use replace, it should overwrite the old value
-	err = c.Replace("foo", []byte("newbar"), 0)
+	_, err = c.Replace("foo", []byte("newbar"), 0)
 	if err != nil {
 		t.Fatal("got an unexpected error from Replace")
 	}
@@ -42,7 +50,7 @@ func TestBasic(t *testing.T) {
 	}
 
 	// try to replace something that doesn't exist, it shouldn't add anything
-	err = c.Replace("yuk", []byte("doesn'tmatter"), 0)
+	_, err = c.Replace("yuk", []byte("doesn'tmatter"), 0)
 	if err != ErrNotFound {
 		t.Fatal("should have gotten a NotFound error from Replace")
 	}
@@ -51,15 +59,15 @@ func TestBasic(t *testing.T) {
 	}
 
 	// now push our first item "foo" off the LRU by adding three new items
-	err = c.Add("bar", []byte("stuff"), 0)
+	_, err = c.Add("bar", []byte("stuff"), 0)
 	if err != nil {
 		t.Fatal("Add returned an error")
 	}
-	err = c.Add("thing", []byte("other"), 0)
+	_, err = c.Add("thing", []byte("other"), 0)
 	if err != nil {
 		t.Fatal("Add returned an error")
 	}
-	err = c.Add("another", []byte("thing"), 0)
+	_, err = c.Add("another", []byte("thing"), 0)
 	if err != nil {
 		t.Fatal("Add returned an error")
 	}
@@ -68,7 +76,7 @@ func TestBasic(t *testing.T) {
 		t.Fatalf("'foo' should be gone now but it still returned a value: %s", v)
 	}
 
-	c.Delete("bar")
+	c.Delete("bar", 0)
 	if v, err := c.Get("bar"); err != ErrNotFound {
 		t.Fatalf("'bar' should have been deleted: %s", v)
 	}
@@ -96,13 +104,16 @@ func TestCAS(t *testing.T) {
 	}
 
 	// set something new using the cas value, should succeed
-	err = c.Cas("castest", []byte("new stuff"), 0, n)
+	cas, err := c.Cas("castest", []byte("new stuff"), 0, n)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if cas != 2 {
+		t.Fatalf("Cas should return the new CAS, got %d", cas)
+	}
 
 	// set something new using the wrong cas value, should fail
-	err = c.Cas("castest", []byte("nope"), 0, 100)
+	_, err = c.Cas("castest", []byte("nope"), 0, 100)
 	if err != ErrExists {
 		t.Fatal(err)
 	}
@@ -116,7 +127,7 @@ func TestCAS(t *testing.T) {
 		t.Fatal("stored value doesn't look right")
 	}
 
-	err = c.Cas("castest", []byte("yup"), 0, 2)
+	_, err = c.Cas("castest", []byte("yup"), 0, 2)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -130,10 +141,13 @@ func TestCAS(t *testing.T) {
 		t.Fatalf("stored value doesn't look right: %d %s", n, v)
 	}
 
-	err = c.Touch("castest", 0)
+	cas, err = c.Touch("castest", 0, 0)
 	if err != nil {
 		t.Logf("touch of 'castest' failed: %s", err)
 	}
+	if cas != 4 {
+		t.Fatalf("Touch should return the new CAS, got %d", cas)
+	}
 
 	_, n, err = c.Gets("castest")
 	if err != nil {
@@ -151,7 +165,7 @@ func TestPrependAppend(t *testing.T) {
 	c.Set("foo", []byte("bar"), 0)
 	c.Set("yuk", []byte("woof"), 0)
 
-	err := c.Append("foo", []byte("stuff"), 0)
+	_, err := c.Append("foo", []byte("stuff"), 0, 0)
 	if err != nil {
 		t.Fatal("got an error trying to append")
 	}
@@ -160,7 +174,7 @@ func TestPrependAppend(t *testing.T) {
 		t.Fatalf("append failed: %s %s", v, err)
 	}
 
-	err = c.Prepend("yuk", []byte("things"), 0)
+	_, err = c.Prepend("yuk", []byte("things"), 0, 0)
 	if err != nil {
 		t.Fatalf("got an error trying to prepend")
 	}
@@ -170,6 +184,151 @@ func TestPrependAppend(t *testing.T) {
 	}
 }
 
+func TestCASGuarded(t *testing.T) {
+	c := New(0)
+	c.Set("foo", []byte("bar"), 0)
+	_, cas, _ := c.Gets("foo")
+
+	if _, err := c.Touch("foo", 0, cas+1); err != ErrExists {
+		t.Fatalf("expected ErrExists touching with the wrong CAS: %v", err)
+	}
+	if _, err := c.Append("foo", []byte("x"), 0, cas+1); err != ErrExists {
+		t.Fatalf("expected ErrExists appending with the wrong CAS: %v", err)
+	}
+	if _, err := c.Prepend("foo", []byte("x"), 0, cas+1); err != ErrExists {
+		t.Fatalf("expected ErrExists prepending with the wrong CAS: %v", err)
+	}
+	if err := c.Delete("foo", cas+1); err != ErrExists {
+		t.Fatalf("expected ErrExists deleting with the wrong CAS: %v", err)
+	}
+	if v, err := c.Get("foo"); err != nil || string(v) != "bar" {
+		t.Fatalf("item changed by a failed guarded operation: %s %v", v, err)
+	}
+
+	newCas, err := c.Append("foo", []byte("baz"), 0, cas)
+	if err != nil {
+		t.Fatalf("got an error appending with the right CAS: %v", err)
+	}
+	if _, stored, _ := c.Gets("foo"); newCas == cas || newCas != stored {
+		t.Fatalf("append returned CAS %d, stored %d", newCas, stored)
+	}
+	if err := c.Delete("foo", cas); err != ErrExists {
+		t.Fatalf("expected ErrExists deleting with the old CAS: %v", err)
+	}
+	if err := c.Delete("foo", newCas); err != nil {
+		t.Fatalf("got an error deleting with the right CAS: %v", err)
+	}
+	if err := c.Delete("foo", newCas); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound deleting a missing item with a CAS: %v", err)
+	}
+}
+
+func TestGetSetDel(t *testing.T) {
+	c := New(0)
+
+	old, existed, cas, err := c.GetSet("foo", []byte("bar"), 0)
+	if err != nil || existed || old != nil {
+		t.Fatalf("GetSet of a new item returned %s %v %v", old, existed, err)
+	}
+	old, existed, newCas, err := c.GetSet("foo", []byte("baz"), 0)
+	if err != nil || !existed || string(old) != "bar" || newCas == cas {
+		t.Fatalf("GetSet returned %s %v %d %v", old, existed, newCas, err)
+	}
+
+	old, existed, cas, err = c.SetNX("foo", []byte("nope"), 0)
+	if err != nil || !existed || string(old) != "baz" || cas != newCas {
+		t.Fatalf("SetNX of an existing item returned %s %v %d %v", old, existed, cas, err)
+	}
+	if v, _ := c.Get("foo"); string(v) != "baz" {
+		t.Fatalf("SetNX replaced an existing item: %s", v)
+	}
+	if _, existed, _, err = c.SetNX("token", []byte("once"), 0); err != nil || existed {
+		t.Fatalf("SetNX of a new item returned %v %v", existed, err)
+	}
+
+	v, _, err := c.GetDel("token")
+	if err != nil || string(v) != "once" {
+		t.Fatalf("GetDel returned %s %v", v, err)
+	}
+	if _, _, err = c.GetDel("token"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound from a second GetDel: %v", err)
+	}
+
+	c.HSet("hash", map[string][]byte{"a": []byte("1")}, 0)
+	if _, _, _, err = c.GetSet("hash", []byte("x"), 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType from GetSet of a hash: %v", err)
+	}
+	if _, _, err = c.GetDel("hash"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType from GetDel of a hash: %v", err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	c := New(0)
+	c.Set("log", []byte("hello world"), 0)
+
+	for _, test := range []struct {
+		start, stop int
+		expected    string
+	}{
+		{0, 4, "hello"},
+		{-5, -1, "world"},
+		{6, 100, "world"},
+		{5, 2, ""},
+	} {
+		if v, err := c.GetRange("log", test.start, test.stop); err != nil || string(v) != test.expected {
+			t.Fatalf("GetRange(%d, %d) returned %q %v, expected %q", test.start, test.stop, v, err, test.expected)
+		}
+	}
+	if v, err := c.GetRange("missing", 0, -1); err != nil || len(v) != 0 {
+		t.Fatalf("expected an empty range of a missing key: %q %v", v, err)
+	}
+
+	_, cas, _ := c.Gets("log")
+	c.MetaDelete("log", MetaDeleteOptions{Invalidate: true})
+	c.MetaGet("log", MetaGetOptions{})
+	n, newCas, err := c.SetRange("log", 6, []byte("there"))
+	if err != nil || n != 11 || newCas == cas {
+		t.Fatalf("SetRange within the value returned %d %d %v", n, newCas, err)
+	}
+	// like any other new value, it clears the stale item and its win
+	if result, _ := c.MetaGet("log", MetaGetOptions{}); result.Stale || result.Win || result.WinSent || result.HitBefore {
+		t.Fatalf("expected SetRange to clear the meta flags: %+v", result)
+	}
+	if v, _ := c.Get("log"); string(v) != "hello there" {
+		t.Fatalf("SetRange within the value left %q", v)
+	}
+	if n, _, err = c.SetRange("log", 13, []byte("!")); err != nil || n != 14 {
+		t.Fatalf("SetRange past the end returned %d %v", n, err)
+	}
+	if v, _ := c.Get("log"); string(v) != "hello there\x00\x00!" {
+		t.Fatalf("SetRange past the end left %q", v)
+	}
+	if n, _, err = c.SetRange("padded", 3, []byte("x")); err != nil || n != 4 {
+		t.Fatalf("SetRange of a missing key returned %d %v", n, err)
+	}
+	if _, _, err = c.SetRange("log", -1, []byte("x")); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for a negative offset: %v", err)
+	}
+	// large enough for offset+len to overflow
+	for _, key := range []string{"log", "missing"} {
+		if _, _, err = c.SetRange(key, math.MaxInt64-2, []byte("hello")); err != ErrInvalid {
+			t.Fatalf("expected ErrInvalid for a huge offset: %v", err)
+		}
+	}
+
+	if n, err := c.StrLen("log"); err != nil || n != 14 {
+		t.Fatalf("StrLen returned %d %v", n, err)
+	}
+	if n, err := c.StrLen("missing"); err != nil || n != 0 {
+		t.Fatalf("StrLen of a missing key returned %d %v", n, err)
+	}
+	c.LPush("list", [][]byte{[]byte("a")}, 0)
+	if _, err := c.StrLen("list"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for StrLen of a list: %v", err)
+	}
+}
+
 func TestScratch(t *testing.T) {
 	v := []byte("one")
 	t.Log(string(append(v, []byte("two")...)))
@@ -230,10 +389,13 @@ func TestIncrementDecrement(t *testing.T) {
 	c.Set("foo", r, 0)
 
 	// increment foo by 20
-	err := c.Increment("foo", 20)
+	n, cas, err := c.Increment("foo", 20)
 	if err != nil {
 		t.Fatalf("error trying to increment foo: %s", err)
 	}
+	if _, stored, _ := c.Gets("foo"); n != 40 || cas != stored {
+		t.Fatalf("increment returned %d and CAS %d, expected 40 and %d", n, cas, stored)
+	}
 
 	// pull foo back out
 	v, err := c.Get("foo")
@@ -253,9 +415,9 @@ func TestIncrementDecrement(t *testing.T) {
 	}
 
 	// now derement it by 10
-	err = c.Decrement("foo", 10)
-	if err != nil {
-		t.Fatalf("error trying to decrement foo: %s", err)
+	n, _, err = c.Decrement("foo", 10)
+	if err != nil || n != 30 {
+		t.Fatalf("error trying to decrement foo: %d %v", n, err)
 	}
 
 	// pull foo back out
@@ -293,20 +455,1365 @@ func TestTTL(t *testing.T) {
 }
 
 func BenchmarkSet(b *testing.B) {
-	c := New(4096)
+	benchmarkSet(b, New(4096))
+}
+
+func BenchmarkGet(b *testing.B) {
+	benchmarkGet(b, New(4096))
+}
 
+func benchmarkSet(b *testing.B, c benchmarkCache) {
 	for i := 0; i < b.N; i++ {
 		c.Set("foo", []byte("bench"), 0)
 	}
 
 }
 
-func BenchmarkGet(b *testing.B) {
-	c := New(4096)
-
+func benchmarkGet(b *testing.B, c benchmarkCache) {
 	c.Set("foo", []byte("bench"), 0)
 	for i := 0; i < b.N; i++ {
 		c.Get("foo")
 	}
 
 }
+
+// mapTier is a simple in memory Tier for testing.
+type mapTier map[string]tierItem
+
+type tierItem struct {
+	value     []byte
+	cas       uint64
+	expiresAt time.Time
+}
+
+func (m mapTier) Put(key string, value []byte, cas uint64, expiresAt time.Time) error {
+	m[key] = tierItem{value, cas, expiresAt}
+	return nil
+}
+
+func (m mapTier) Get(key string) ([]byte, uint64, time.Time, error) {
+	if i, ok := m[key]; ok {
+		return i.value, i.cas, i.expiresAt, nil
+	}
+	return nil, 0, time.Time{}, ErrNotFound
+}
+
+func (m mapTier) Delete(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m mapTier) Flush() error {
+	for key := range m {
+		delete(m, key)
+	}
+	return nil
+}
+
+func TestTier(t *testing.T) {
+	tier := mapTier{}
+	c := New(2).WithTier(tier)
+
+	c.Set("first", []byte("spilled"), time.Minute)
+	c.Set("second", []byte("val"), 0)
+	c.Set("third", []byte("val"), 0)
+
+	// first was evicted via LRU and should have landed in the tier with its
+	// cas and expiration intact
+	if c.find("first") != nilIndex {
+		t.Fatal("expected 'first' to be evicted from memory")
+	}
+	spilled, ok := tier["first"]
+	if !ok {
+		t.Fatal("expected 'first' to be spilled to the tier")
+	}
+	if spilled.cas != 1 || spilled.expiresAt.IsZero() {
+		t.Fatalf("spilled item doesn't look right: %+v", spilled)
+	}
+
+	// a miss in memory is served from the tier and promoted, which in turn
+	// spills the least recently used item (second)
+	v, cas, err := c.Gets("first")
+	if err != nil || bytes.Compare(v, []byte("spilled")) != 0 || cas != 1 {
+		t.Fatalf("expected 'first' to be promoted from the tier: %s %d %v", v, cas, err)
+	}
+	if _, ok := tier["first"]; ok {
+		t.Fatal("'first' should have been removed from the tier after promotion")
+	}
+	if _, ok := tier["second"]; !ok {
+		t.Fatal("expected 'second' to be spilled to the tier")
+	}
+
+	// add sees items in the tier
+	if _, err := c.Add("second", []byte("nope"), 0); err != ErrExists {
+		t.Fatalf("expected ErrExists adding an item that's in the tier: %v", err)
+	}
+
+	// deletes and flushes reach the tier too
+	c.Set("fourth", []byte("val"), 0)
+	c.Delete("second", 0)
+	if _, err := c.Get("second"); err != ErrNotFound {
+		t.Fatal("'second' should have been deleted")
+	}
+	c.FlushAll()
+	if len(tier) != 0 {
+		t.Fatalf("expected the tier to be flushed: %v", tier)
+	}
+}
+
+func TestTierStaleCopy(t *testing.T) {
+	tier := mapTier{}
+	c := New(1).WithTier(tier)
+
+	// setting an item that's been spilled replaces the copy in the tier, so
+	// it can't come back once the new value expires...
+	c.Set("a", []byte("old"), 0)
+	c.Set("b", []byte("b"), 0)
+	c.Set("a", []byte("new"), 50*time.Millisecond)
+	if _, ok := tier["a"]; ok {
+		t.Fatal("the old copy of 'a' should have been removed from the tier")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if v, err := c.Get("a"); err != ErrNotFound {
+		t.Fatalf("expected 'a' to have expired, got %s %v", v, err)
+	}
+
+	// ...or is consumed
+	c.Set("k", []byte("v1"), 0)
+	c.Set("b", []byte("b"), 0)
+	c.Set("k", []byte("v2"), 0)
+	if v, _, err := c.GetDel("k"); err != nil || string(v) != "v2" {
+		t.Fatalf("GetDel returned %s %v", v, err)
+	}
+	if v, err := c.Get("k"); err != ErrNotFound {
+		t.Fatalf("expected 'k' to be gone, got %s %v", v, err)
+	}
+}
+
+func TestSlabStorage(t *testing.T) {
+	c := New(0)
+
+	// values across several size classes, including one too big for any
+	values := map[string][]byte{}
+	for i := 0; i < 1000; i++ {
+		key := "key:" + strconv.Itoa(i)
+		values[key] = bytes.Repeat([]byte{byte(i)}, i*i%5000)
+		c.Set(key, values[key], 0)
+	}
+	values["huge"] = bytes.Repeat([]byte("h"), slabPageSize*2)
+	c.Set("huge", values["huge"], 0)
+
+	// delete every other item, freeing chunks and entries for reuse
+	for i := 0; i < 1000; i += 2 {
+		key := "key:" + strconv.Itoa(i)
+		c.Delete(key, 0)
+		delete(values, key)
+	}
+	// grow and shrink some of the remaining values so they change classes
+	for i := 1; i < 1000; i += 6 {
+		key := "key:" + strconv.Itoa(i)
+		c.Append(key, bytes.Repeat([]byte("a"), 300), 0, 0)
+		values[key] = append(values[key], bytes.Repeat([]byte("a"), 300)...)
+	}
+	for i := 3; i < 1000; i += 6 {
+		key := "key:" + strconv.Itoa(i)
+		c.Set(key, []byte("small"), 0)
+		values[key] = []byte("small")
+	}
+	// and reuse the freed entries
+	for i := 0; i < 500; i++ {
+		key := "new:" + strconv.Itoa(i)
+		values[key] = []byte(key)
+		c.Set(key, values[key], 0)
+	}
+
+	if c.Len() != len(values) {
+		t.Fatalf("expected %d items, got %d", len(values), c.Len())
+	}
+	for key, value := range values {
+		v, err := c.Get(key)
+		if err != nil {
+			t.Fatalf("error getting %s: %s", key, err)
+		}
+		if bytes.Compare(v, value) != 0 {
+			t.Fatalf("value for %s doesn't look right (%d bytes, expected %d)", key, len(v), len(value))
+		}
+	}
+
+	// values handed out (other than by Get and Gets) are copies, so modifying
+	// them doesn't touch the cache
+	v, _, _ := c.Peek("new:1")
+	v[0] = 'X'
+	if v, _ := c.Get("new:1"); string(v) != "new:1" {
+		t.Fatalf("cached value was modified through a returned slice: %s", v)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	c := New(2)
+
+	if _, err := c.Inspect("nope"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound inspecting a missing item: %v", err)
+	}
+
+	c.Set("foo", []byte("bar"), time.Minute)
+	c.Set("yuk", []byte("woof"), 0)
+	time.Sleep(time.Millisecond * 5)
+
+	info, err := c.Inspect("foo")
+	if err != nil {
+		t.Fatalf("error inspecting foo: %s", err)
+	}
+	if info.Size != 3 || info.CAS != 1 || info.Tiered {
+		t.Fatalf("info doesn't look right: %+v", info)
+	}
+	if info.Age < time.Millisecond*5 || info.LastAccess < time.Millisecond*5 {
+		t.Fatalf("age and last access should be at least 5ms: %+v", info)
+	}
+	if info.TTL <= 0 || info.TTL > time.Minute-time.Millisecond*5 {
+		t.Fatalf("remaining ttl doesn't look right: %s", info.TTL)
+	}
+	if info, _ := c.Inspect("yuk"); info.TTL != 0 {
+		t.Fatalf("expected no ttl for yuk: %s", info.TTL)
+	}
+
+	// inspecting foo doesn't promote it, so it's still the least recently
+	// used item and is the one evicted
+	c.Set("third", []byte("val"), 0)
+	if _, err := c.Inspect("foo"); err != ErrNotFound {
+		t.Fatal("expected 'foo' to be evicted since Inspect shouldn't promote it")
+	}
+
+	// and it doesn't change the cas or the last access time
+	before, _ := c.Inspect("yuk")
+	time.Sleep(time.Millisecond * 2)
+	after, _ := c.Inspect("yuk")
+	if before.CAS != after.CAS || after.LastAccess <= before.LastAccess {
+		t.Fatalf("inspect modified the item: %+v %+v", before, after)
+	}
+	// reads are only timed to the second
+	time.Sleep(time.Second + time.Millisecond*100)
+	after, _ = c.Inspect("yuk")
+	c.Get("yuk")
+	if info, _ := c.Inspect("yuk"); info.LastAccess >= after.LastAccess {
+		t.Fatalf("get should have reset the last access time: %+v", info)
+	}
+}
+
+// benchmarkItems is the number of items used to fill the cache in the
+// allocation and GC benchmarks below.
+const benchmarkItems = 1 << 20
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// BenchmarkSetEvict sets distinct keys into a full cache, so every Set also
+// evicts an item.
+func BenchmarkSetEvict(b *testing.B) {
+	benchmarkSetEvict(b, func(maxEntries int) benchmarkCache { return New(maxEntries) })
+}
+
+// BenchmarkGetMany gets random keys from a full cache.
+func BenchmarkGetMany(b *testing.B) {
+	benchmarkGetMany(b, New(0))
+}
+
+// BenchmarkGC measures how long a full garbage collection takes with a cache
+// of benchmarkItems small items live on the heap. ns/op is the GC time.
+func BenchmarkGC(b *testing.B) {
+	benchmarkGC(b, New(0))
+}
+
+func benchmarkSetEvict(b *testing.B, newCache func(maxEntries int) benchmarkCache) {
+	keys := benchmarkKeys(benchmarkItems)
+	value := []byte("0123456789abcdef0123456789abcdef")
+	c := newCache(benchmarkItems / 2)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(keys[i%len(keys)], value, 0)
+	}
+}
+
+func benchmarkGetMany(b *testing.B, c benchmarkCache) {
+	keys := benchmarkKeys(benchmarkItems)
+	value := []byte("0123456789abcdef0123456789abcdef")
+	for _, key := range keys {
+		c.Set(key, value, 0)
+	}
+	// look up with separate copies of the keys, as a server decoding them
+	// from requests would, so comparing keys has to read the stored ones
+	keys = benchmarkKeys(benchmarkItems)
+	r := rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get(keys[r.Intn(len(keys))])
+	}
+}
+
+func benchmarkGC(b *testing.B, c benchmarkCache) {
+	keys := benchmarkKeys(benchmarkItems)
+	for _, key := range keys {
+		c.Set(key, []byte("0123456789abcdef0123456789abcdef"), 0)
+	}
+	keys = nil
+	runtime.GC()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+	b.StopTimer()
+	runtime.KeepAlive(c)
+}
+
+// benchmarkCache is what the benchmarks need from a cache, so they can be run
+// against both Cache and listCache.
+type benchmarkCache interface {
+	Set(key string, value []byte, ttl time.Duration) (uint64, error)
+	Get(key string) ([]byte, error)
+}
+
+// listCache is the storage Cache used before slabs: a container/list for the
+// LRU and a map of keys to its elements, with values kept as the caller's
+// slices. It only does what the benchmarks need, and is kept so the
+// comparison in the README can be re-run with the BenchmarkList benchmarks.
+type listCache struct {
+	maxEntries int
+	lruList    *stdlist.List
+	cache      map[string]*stdlist.Element
+	casID      uint64
+}
+
+type listEntry struct {
+	key       string
+	value     []byte
+	cas       uint64
+	ttl       time.Duration
+	createdAt time.Time
+}
+
+func newListCache(maxEntries int) *listCache {
+	return &listCache{
+		maxEntries: maxEntries,
+		lruList:    stdlist.New(),
+		cache:      make(map[string]*stdlist.Element),
+	}
+}
+
+func (c *listCache) Set(key string, value []byte, ttl time.Duration) (uint64, error) {
+	c.casID++
+	if ele, ok := c.cache[key]; ok {
+		c.lruList.MoveToFront(ele)
+		e := ele.Value.(*listEntry)
+		e.value = value
+		e.ttl = ttl
+		e.createdAt = time.Now()
+		e.cas = c.casID
+		return e.cas, nil
+	}
+	c.cache[key] = c.lruList.PushFront(&listEntry{key: key, value: value, ttl: ttl, createdAt: time.Now(), cas: c.casID})
+	for c.maxEntries != 0 && c.lruList.Len() > c.maxEntries {
+		c.remove(c.lruList.Back())
+	}
+	return c.casID, nil
+}
+
+func (c *listCache) Get(key string) ([]byte, error) {
+	ele, ok := c.cache[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	e := ele.Value.(*listEntry)
+	if e.ttl != 0 && time.Since(e.createdAt) > e.ttl {
+		c.remove(ele)
+		return nil, ErrNotFound
+	}
+	c.lruList.MoveToFront(ele)
+	return e.value, nil
+}
+
+func (c *listCache) remove(ele *stdlist.Element) {
+	c.lruList.Remove(ele)
+	delete(c.cache, ele.Value.(*listEntry).key)
+}
+
+func BenchmarkListSet(b *testing.B) {
+	benchmarkSet(b, newListCache(4096))
+}
+
+func BenchmarkListGet(b *testing.B) {
+	benchmarkGet(b, newListCache(4096))
+}
+
+func BenchmarkListSetEvict(b *testing.B) {
+	benchmarkSetEvict(b, func(maxEntries int) benchmarkCache { return newListCache(maxEntries) })
+}
+
+func BenchmarkListGetMany(b *testing.B) {
+	benchmarkGetMany(b, newListCache(0))
+}
+
+func BenchmarkListGC(b *testing.B) {
+	benchmarkGC(b, newListCache(0))
+}
+
+func TestMetaGet(t *testing.T) {
+	c := New(0)
+
+	if _, err := c.MetaGet("foo", MetaGetOptions{}); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a missing item: %v", err)
+	}
+
+	// vivify on miss: the first client wins, the next sees win sent
+	result, err := c.MetaGet("foo", MetaGetOptions{Vivify: true, VivifyTTL: time.Minute})
+	if err != nil || !result.Win || !result.Vivified || len(result.Value) != 0 {
+		t.Fatalf("expected an empty vivified item with win: %+v %v", result, err)
+	}
+	result, _ = c.MetaGet("foo", MetaGetOptions{Vivify: true})
+	if result.Win || result.Vivified || !result.WinSent {
+		t.Fatalf("expected win sent for the second client: %+v", result)
+	}
+
+	// the real value clears the win
+	c.Set("foo", []byte("bar"), time.Minute)
+	result, _ = c.MetaGet("foo", MetaGetOptions{})
+	if result.Win || result.WinSent || result.HitBefore || string(result.Value) != "bar" {
+		t.Fatalf("expected a fresh item: %+v", result)
+	}
+	result, _ = c.MetaGet("foo", MetaGetOptions{})
+	if !result.HitBefore {
+		t.Fatalf("expected hit before on the second get: %+v", result)
+	}
+
+	// recache when the remaining ttl drops below recache ttl
+	result, _ = c.MetaGet("foo", MetaGetOptions{RecacheTTL: time.Hour})
+	if !result.Win {
+		t.Fatalf("expected win when ttl is below the recache ttl: %+v", result)
+	}
+	result, _ = c.MetaGet("foo", MetaGetOptions{RecacheTTL: time.Hour})
+	if result.Win || !result.WinSent {
+		t.Fatalf("expected only one win: %+v", result)
+	}
+
+	// touch
+	result, _ = c.MetaGet("foo", MetaGetOptions{Touch: true, TTL: time.Hour})
+	if result.TTL <= time.Minute {
+		t.Fatalf("expected touch to extend the ttl: %s", result.TTL)
+	}
+
+	// no bump leaves the LRU alone
+	c = New(2)
+	c.Set("foo", []byte("bar"), 0)
+	c.Set("yuk", []byte("woof"), 0)
+	c.MetaGet("foo", MetaGetOptions{NoBump: true})
+	c.Set("third", []byte("val"), 0)
+	if _, err := c.Get("foo"); err != ErrNotFound {
+		t.Fatal("expected 'foo' to be evicted since it wasn't bumped")
+	}
+}
+
+func TestMetaSetDelete(t *testing.T) {
+	c := New(0)
+
+	if _, err := c.MetaSet("foo", []byte("bar"), MetaSetOptions{Mode: MetaSetModeReplace}); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound replacing a missing item: %v", err)
+	}
+	cas, err := c.MetaSet("foo", []byte("bar"), MetaSetOptions{Mode: MetaSetModeAdd})
+	if err != nil {
+		t.Fatalf("error adding foo: %s", err)
+	}
+	if _, err := c.MetaSet("foo", []byte("bar"), MetaSetOptions{Mode: MetaSetModeAdd}); err != ErrExists {
+		t.Fatalf("expected ErrExists adding an existing item: %v", err)
+	}
+	if _, err := c.MetaSet("foo", []byte("baz"), MetaSetOptions{CAS: cas + 1}); err != ErrExists {
+		t.Fatalf("expected ErrExists for a mismatched cas: %v", err)
+	}
+	cas, err = c.MetaSet("foo", []byte("!"), MetaSetOptions{Mode: MetaSetModeAppend, CAS: cas})
+	if v, _ := c.Get("foo"); err != nil || string(v) != "bar!" {
+		t.Fatalf("expected append to produce 'bar!': %s %v", v, err)
+	}
+	if _, err := c.MetaSet("new", []byte("x"), MetaSetOptions{Mode: MetaSetModePrepend, Vivify: true}); err != nil {
+		t.Fatalf("expected prepend to vivify a missing item: %v", err)
+	}
+
+	// invalidate: the item stays, marked stale, and the next get wins
+	if err := c.MetaDelete("foo", MetaDeleteOptions{Invalidate: true}); err != nil {
+		t.Fatalf("error invalidating foo: %s", err)
+	}
+	result, err := c.MetaGet("foo", MetaGetOptions{})
+	if err != nil || !result.Stale || !result.Win || string(result.Value) != "bar!" {
+		t.Fatalf("expected the stale value with win: %+v %v", result, err)
+	}
+	result, _ = c.MetaGet("foo", MetaGetOptions{})
+	if !result.Stale || result.Win || !result.WinSent {
+		t.Fatalf("expected the stale value with win sent: %+v", result)
+	}
+
+	// a set with the older cas is stored, but stays stale
+	if _, err := c.MetaSet("foo", []byte("old"), MetaSetOptions{CAS: cas, Invalidate: true}); err != nil {
+		t.Fatalf("error setting with an older cas: %s", err)
+	}
+	if result, _ = c.MetaGet("foo", MetaGetOptions{}); !result.Stale || string(result.Value) != "old" {
+		t.Fatalf("expected the item to still be stale: %+v", result)
+	}
+	c.MetaSet("foo", []byte("new"), MetaSetOptions{})
+	if result, _ = c.MetaGet("foo", MetaGetOptions{}); result.Stale {
+		t.Fatalf("expected a plain set to clear stale: %+v", result)
+	}
+
+	if err := c.MetaDelete("foo", MetaDeleteOptions{CAS: 1}); err != ErrExists {
+		t.Fatalf("expected ErrExists deleting with a mismatched cas: %v", err)
+	}
+	if err := c.MetaDelete("foo", MetaDeleteOptions{}); err != nil {
+		t.Fatalf("error deleting foo: %s", err)
+	}
+	if err := c.MetaDelete("foo", MetaDeleteOptions{}); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound deleting a missing item: %v", err)
+	}
+}
+
+func TestMetaArithmetic(t *testing.T) {
+	c := New(0)
+
+	if _, _, err := c.MetaArithmetic("n", MetaArithmeticOptions{Delta: 1}); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a missing counter: %v", err)
+	}
+	n, _, err := c.MetaArithmetic("n", MetaArithmeticOptions{Delta: 1, Vivify: true, Initial: 10})
+	if err != nil || n != 10 {
+		t.Fatalf("expected a vivified counter of 10: %d %v", n, err)
+	}
+	n, cas, _ := c.MetaArithmetic("n", MetaArithmeticOptions{Delta: 5})
+	if n != 15 {
+		t.Fatalf("expected 15, got %d", n)
+	}
+	if _, _, err := c.MetaArithmetic("n", MetaArithmeticOptions{Delta: 1, CAS: cas + 1}); err != ErrExists {
+		t.Fatalf("expected ErrExists for a mismatched cas: %v", err)
+	}
+	// decrement stops at zero
+	if n, _, _ = c.MetaArithmetic("n", MetaArithmeticOptions{Decrement: true, Delta: 100}); n != 0 {
+		t.Fatalf("expected decrement to stop at 0, got %d", n)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	c := New(2)
+
+	if _, _, err := c.Peek("nope"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound peeking a missing item: %v", err)
+	}
+
+	c.Set("foo", []byte("bar"), 0)
+	c.Set("yuk", []byte("woof"), 0)
+	value, cas, err := c.Peek("foo")
+	if err != nil || bytes.Compare(value, []byte("bar")) != 0 || cas != 1 {
+		t.Fatalf("peeked value doesn't look right: %s %d %v", value, cas, err)
+	}
+
+	// peeking foo doesn't promote it, so it's still the one evicted
+	c.Set("third", []byte("val"), 0)
+	if _, _, err := c.Peek("foo"); err != ErrNotFound {
+		t.Fatal("expected 'foo' to be evicted since Peek shouldn't promote it")
+	}
+
+	// expired items are reported missing but left for a writer to evict
+	c.Set("short", []byte("lived"), time.Millisecond)
+	time.Sleep(time.Millisecond * 2)
+	if _, _, err := c.Peek("short"); err != ErrNotFound {
+		t.Fatal("expected 'short' to have expired")
+	}
+	if c.find("short") == nilIndex {
+		t.Fatal("Peek shouldn't have evicted 'short'")
+	}
+
+	// concurrent peeks under the read lock
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.RLock()
+				c.Peek("third")
+				c.RUnlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestHash(t *testing.T) {
+	c := New(0)
+
+	added, err := c.HSet("user", map[string][]byte{"name": []byte("josh"), "visits": []byte("1")}, time.Minute)
+	if err != nil || added != 2 {
+		t.Fatalf("expected 2 fields added: %d %v", added, err)
+	}
+	if added, _ = c.HSet("user", map[string][]byte{"name": []byte("joshua"), "city": []byte("sf")}, 0); added != 1 {
+		t.Fatalf("expected 1 field added: %d", added)
+	}
+	if value, err := c.HGet("user", "name"); err != nil || string(value) != "joshua" {
+		t.Fatalf("expected the updated name: %s %v", value, err)
+	}
+	if _, err := c.HGet("user", "nope"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a missing field: %v", err)
+	}
+	if n, _ := c.HLen("user"); n != 3 {
+		t.Fatalf("expected 3 fields, got %d", n)
+	}
+	if n, err := c.HIncrBy("user", "visits", 41); err != nil || n != 42 {
+		t.Fatalf("expected visits to be 42: %d %v", n, err)
+	}
+	if _, err := c.HIncrBy("user", "name", 1); err != ErrNotInteger {
+		t.Fatalf("expected ErrNotInteger incrementing a string: %v", err)
+	}
+	if _, err := c.HIncrBy("user", "visits", math.MaxInt64); err != ErrOverflow {
+		t.Fatalf("expected ErrOverflow incrementing past the largest int64: %v", err)
+	}
+	if _, err := c.HIncrBy("user", "visits", math.MinInt64); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.HIncrBy("user", "visits", -43); err != ErrOverflow {
+		t.Fatalf("expected ErrOverflow decrementing past the smallest int64: %v", err)
+	}
+	if n, err := c.HIncrBy("user", "visits", math.MaxInt64); err != nil || n != 41 {
+		t.Fatalf("expected visits to be 41: %d %v", n, err)
+	}
+	c.HIncrBy("user", "visits", 1)
+	fields, err := c.HGetAll("user")
+	if err != nil || len(fields) != 3 || string(fields["visits"]) != "42" {
+		t.Fatalf("unexpected fields: %v %v", fields, err)
+	}
+
+	// the ttl applies to the whole hash, and it's one LRU entry
+	if info, _ := c.Inspect("user"); info.TTL <= 0 || c.Len() != 1 {
+		t.Fatalf("expected a single entry with a ttl: %+v %d", info, c.Len())
+	}
+
+	// type errors both ways
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.HGet("plain", "field"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a hash op on a plain value: %v", err)
+	}
+	if _, err := c.Get("user"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType getting a hash: %v", err)
+	}
+	if _, _, err := c.Increment("user", 1); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType incrementing a hash: %v", err)
+	}
+
+	// deleting the last field deletes the hash
+	if n, _ := c.HDel("user", "name", "city", "nope"); n != 2 {
+		t.Fatalf("expected 2 fields deleted, got %d", n)
+	}
+	c.HDel("user", "visits")
+	if _, err := c.HGetAll("user"); err != ErrNotFound {
+		t.Fatalf("expected the empty hash to be deleted: %v", err)
+	}
+
+	// set replaces a hash with a plain value
+	c.HSet("user", map[string][]byte{"name": []byte("josh")}, 0)
+	c.Set("user", []byte("plain"), 0)
+	if v, err := c.Get("user"); err != nil || string(v) != "plain" {
+		t.Fatalf("expected set to replace the hash: %s %v", v, err)
+	}
+}
+
+func TestList(t *testing.T) {
+	c := New(0)
+
+	values := func(bs [][]byte) string {
+		s := ""
+		for _, b := range bs {
+			s += string(b)
+		}
+		return s
+	}
+
+	if n, err := c.RPush("list", [][]byte{[]byte("c"), []byte("d")}, 0); err != nil || n != 2 {
+		t.Fatalf("expected a length of 2: %d %v", n, err)
+	}
+	if n, _ := c.LPush("list", [][]byte{[]byte("b"), []byte("a")}, time.Minute); n != 4 {
+		t.Fatalf("expected a length of 4: %d", n)
+	}
+	if vs, _ := c.LRange("list", 0, -1); values(vs) != "abcd" {
+		t.Fatalf("expected abcd, got %s", values(vs))
+	}
+	if vs, _ := c.LRange("list", -3, 1); values(vs) != "b" {
+		t.Fatalf("expected b, got %s", values(vs))
+	}
+	if vs, _ := c.LRange("list", 2, 100); values(vs) != "cd" {
+		t.Fatalf("expected cd, got %s", values(vs))
+	}
+	if vs, _ := c.LRange("list", 3, 1); len(vs) != 0 {
+		t.Fatalf("expected an empty range, got %s", values(vs))
+	}
+	if v, _ := c.LPop("list"); string(v) != "a" {
+		t.Fatalf("expected a, got %s", v)
+	}
+	if v, _ := c.RPop("list"); string(v) != "d" {
+		t.Fatalf("expected d, got %s", v)
+	}
+	if n, _ := c.LLen("list"); n != 2 {
+		t.Fatalf("expected a length of 2, got %d", n)
+	}
+
+	// capped list
+	c.RPush("list", [][]byte{[]byte("e"), []byte("f")}, 0)
+	c.LTrim("list", -2, -1)
+	if vs, _ := c.LRange("list", 0, -1); values(vs) != "ef" {
+		t.Fatalf("expected ef after trimming, got %s", values(vs))
+	}
+
+	// popping the last element deletes the list
+	c.LPop("list")
+	c.LPop("list")
+	if _, err := c.LPop("list"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound popping an empty list: %v", err)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected the empty list to be deleted, %d items left", c.Len())
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.LPush("plain", [][]byte{[]byte("x")}, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType pushing to a plain value: %v", err)
+	}
+	c.HSet("hash", map[string][]byte{"f": []byte("v")}, 0)
+	if _, err := c.LLen("hash"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a list op on a hash: %v", err)
+	}
+}
+
+func TestSetType(t *testing.T) {
+	c := New(0)
+
+	join := func(members []string) string {
+		return strings.Join(members, ",")
+	}
+
+	if n, err := c.SAdd("a", []string{"x", "y", "z", "x"}, 0); err != nil || n != 3 {
+		t.Fatalf("expected 3 members added: %d %v", n, err)
+	}
+	c.SAdd("b", []string{"y", "z", "w"}, 0)
+	if ok, _ := c.SIsMember("a", "x"); !ok {
+		t.Fatal("expected x to be a member of a")
+	}
+	if ok, _ := c.SIsMember("a", "w"); ok {
+		t.Fatal("expected w not to be a member of a")
+	}
+	if n, _ := c.SCard("a"); n != 3 {
+		t.Fatalf("expected 3 members, got %d", n)
+	}
+	if m, _ := c.SMembers("a"); join(m) != "x,y,z" {
+		t.Fatalf("unexpected members: %v", m)
+	}
+	if m, _ := c.SInter("a", "b"); join(m) != "y,z" {
+		t.Fatalf("unexpected intersection: %v", m)
+	}
+	if m, _ := c.SInter("a", "b", "missing"); len(m) != 0 {
+		t.Fatalf("expected an empty intersection with a missing set: %v", m)
+	}
+	if m, _ := c.SUnion("a", "b", "missing"); join(m) != "w,x,y,z" {
+		t.Fatalf("unexpected union: %v", m)
+	}
+	if m, _ := c.SDiff("a", "b"); join(m) != "x" {
+		t.Fatalf("unexpected difference: %v", m)
+	}
+	if n, _ := c.SRem("a", "x", "nope"); n != 1 {
+		t.Fatalf("expected 1 member removed, got %d", n)
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.SUnion("a", "plain"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for set algebra with a plain value: %v", err)
+	}
+}
+
+func TestZset(t *testing.T) {
+	c := New(0)
+
+	join := func(members []ScoredMember) string {
+		var s []string
+		for _, m := range members {
+			s = append(s, fmt.Sprintf("%s:%g", m.Member, m.Score))
+		}
+		return strings.Join(s, ",")
+	}
+
+	n, err := c.ZAdd("board", []ScoredMember{{"alice", 30}, {"bob", 10}, {"carol", 20}, {"dave", 20}}, 0)
+	if err != nil || n != 4 {
+		t.Fatalf("expected 4 members added: %d %v", n, err)
+	}
+	if n, _ := c.ZAdd("board", []ScoredMember{{"bob", 15}}, 0); n != 0 {
+		t.Fatalf("expected updating a score not to add a member, got %d", n)
+	}
+	if m, _ := c.ZRange("board", 0, -1, false); join(m) != "bob:15,carol:20,dave:20,alice:30" {
+		t.Fatalf("unexpected range: %s", join(m))
+	}
+	if m, _ := c.ZRange("board", 0, 1, true); join(m) != "alice:30,dave:20" {
+		t.Fatalf("unexpected reverse range: %s", join(m))
+	}
+	if m, _ := c.ZRangeByScore("board", 15, 20, false); join(m) != "bob:15,carol:20,dave:20" {
+		t.Fatalf("unexpected range by score: %s", join(m))
+	}
+	if m, _ := c.ZRangeByScore("board", 16, 100, true); join(m) != "alice:30,dave:20,carol:20" {
+		t.Fatalf("unexpected reverse range by score: %s", join(m))
+	}
+	if r, _ := c.ZRank("board", "carol", false); r != 1 {
+		t.Fatalf("expected carol at rank 1, got %d", r)
+	}
+	if r, _ := c.ZRank("board", "carol", true); r != 2 {
+		t.Fatalf("expected carol at reverse rank 2, got %d", r)
+	}
+	if _, err := c.ZRank("board", "nobody", false); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a missing member: %v", err)
+	}
+	if score, _ := c.ZIncrBy("board", "bob", 100); score != 115 {
+		t.Fatalf("expected bob's score to be 115, got %g", score)
+	}
+	if r, _ := c.ZRank("board", "bob", true); r != 0 {
+		t.Fatalf("expected bob to lead, got rank %d", r)
+	}
+	if n, _ := c.ZRem("board", "alice", "nobody"); n != 1 {
+		t.Fatalf("expected 1 member removed, got %d", n)
+	}
+	if n, _ := c.ZCard("board"); n != 3 {
+		t.Fatalf("expected 3 members, got %d", n)
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.ZAdd("plain", []ScoredMember{{"x", 1}}, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+
+	// check ranks and ranges against the ordered members after random updates
+	for i := 0; i < 2000; i++ {
+		member := fmt.Sprintf("m%d", rand.Intn(500))
+		if rand.Intn(4) == 0 {
+			c.ZRem("random", member)
+		} else {
+			c.ZAdd("random", []ScoredMember{{member, float64(rand.Intn(100))}}, 0)
+		}
+	}
+	expected, _ := c.ZRangeByScore("random", 0, 100, false)
+	if n, _ := c.ZCard("random"); n != len(expected) {
+		t.Fatalf("expected %d members, got %d", len(expected), n)
+	}
+	for i, m := range expected {
+		if i > 0 && (expected[i-1].Score > m.Score || (expected[i-1].Score == m.Score && expected[i-1].Member >= m.Member)) {
+			t.Fatalf("members out of order at %d: %v %v", i, expected[i-1], m)
+		}
+		if r, _ := c.ZRank("random", m.Member, false); r != i {
+			t.Fatalf("expected %s at rank %d, got %d", m.Member, i, r)
+		}
+		if got, _ := c.ZRange("random", i, i, false); len(got) != 1 || got[0] != m {
+			t.Fatalf("expected %v at rank %d, got %v", m, i, got)
+		}
+	}
+}
+
+func TestZsetNaN(t *testing.T) {
+	c := New(0)
+	if _, err := c.ZAdd("z", []ScoredMember{{"a", 1}, {"b", math.NaN()}}, 0); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid adding a NaN score: %v", err)
+	}
+	if n, _ := c.ZCard("z"); n != 0 {
+		t.Fatalf("nothing should have been added: %d", n)
+	}
+	if _, err := c.ZIncrBy("z", "a", math.NaN()); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid incrementing by NaN: %v", err)
+	}
+
+	c.ZAdd("z", []ScoredMember{{"a", math.Inf(1)}}, 0)
+	if _, err := c.ZIncrBy("z", "a", math.Inf(-1)); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for Inf + -Inf: %v", err)
+	}
+	if r, _ := c.ZRange("z", 0, -1, false); len(r) != 1 || !math.IsInf(r[0].Score, 1) {
+		t.Fatalf("the score should be unchanged: %v", r)
+	}
+	if n, _ := c.ZRem("z", "a"); n != 1 {
+		t.Fatalf("expected to remove a: %d", n)
+	}
+	if n, _ := c.ZCard("z"); n != 0 {
+		t.Fatalf("expected an empty sorted set: %d", n)
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	c := New(0)
+
+	within := func(got uint64, expected int) bool {
+		return math.Abs(float64(got)-float64(expected)) <= float64(expected)*0.02
+	}
+
+	for i := 0; i < 10000; i++ {
+		c.PFAdd("a", [][]byte{[]byte(fmt.Sprintf("a-%d", i))}, 0)
+	}
+	for i := 0; i < 10000; i++ {
+		c.PFAdd("b", [][]byte{[]byte(fmt.Sprintf("b-%d", i%5000))}, 0)
+	}
+	if n, _ := c.PFCount("a"); !within(n, 10000) {
+		t.Fatalf("expected about 10000, got %d", n)
+	}
+	if n, _ := c.PFCount("b"); !within(n, 5000) {
+		t.Fatalf("expected about 5000, got %d", n)
+	}
+	if changed, _ := c.PFAdd("b", [][]byte{[]byte("b-1")}, 0); changed {
+		t.Fatal("expected adding an existing element not to change the HyperLogLog")
+	}
+	if n, _ := c.PFCount("small"); n != 0 {
+		t.Fatalf("expected 0 for a missing key, got %d", n)
+	}
+	c.PFAdd("small", [][]byte{[]byte("x"), []byte("y"), []byte("z")}, 0)
+	if n, _ := c.PFCount("small"); n != 3 {
+		t.Fatalf("expected 3, got %d", n)
+	}
+	if n, _ := c.PFCount("a", "b"); !within(n, 15000) {
+		t.Fatalf("expected about 15000 in the union, got %d", n)
+	}
+	if err := c.PFMerge("ab", "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := c.PFCount("ab"); !within(n, 15000) {
+		t.Fatalf("expected about 15000 after merging, got %d", n)
+	}
+
+	// the encoding is a plain value, so it can be saved and restored
+	value, err := c.Get("ab")
+	if err != nil || len(value) > 12*1024+4 {
+		t.Fatalf("expected a compact plain value: %d %v", len(value), err)
+	}
+	c.Delete("ab", 0)
+	c.Set("ab", value, 0)
+	if n, _ := c.PFCount("ab"); !within(n, 15000) {
+		t.Fatalf("expected about 15000 after restoring, got %d", n)
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.PFAdd("plain", [][]byte{[]byte("x")}, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+}
+
+func TestBloom(t *testing.T) {
+	c := New(0)
+
+	if err := c.BFReserve("f", 1000, 0.01, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.BFReserve("f", 1000, 0.01, 0); err != ErrExists {
+		t.Fatalf("expected ErrExists reserving an existing key: %v", err)
+	}
+	if err := c.BFReserve("bad", 1000, 1.5, 0); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for an error rate over 1: %v", err)
+	}
+	if err := c.BFReserve("bad", 1<<32, 0.01, 0); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for a huge capacity: %v", err)
+	}
+	if err := c.BFReserve("bad", 10, 1e-300, 0); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for a tiny error rate: %v", err)
+	}
+	if _, err := c.Get("bad"); err != ErrNotFound {
+		t.Fatalf("no filter should have been created: %v", err)
+	}
+	// an item can already look present when added, at the false positive rate
+	added := 0
+	for i := 0; i < 1000; i++ {
+		if ok, err := c.BFAdd("f", []byte(fmt.Sprintf("in-%d", i)), 0); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			added++
+		}
+	}
+	if added < 980 {
+		t.Fatalf("expected nearly all items to be added, got %d", added)
+	}
+	if added, _ := c.BFAdd("f", []byte("in-1"), 0); added {
+		t.Fatal("expected adding an existing item to return false")
+	}
+	items := make([][]byte, 1000)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf("in-%d", i))
+	}
+	exists, err := c.BFMExists("f", items...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range exists {
+		if !ok {
+			t.Fatalf("expected in-%d to exist", i)
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if ok, _ := c.BFExists("f", []byte(fmt.Sprintf("out-%d", i))); ok {
+			falsePositives++
+		}
+	}
+	if falsePositives > 200 {
+		t.Fatalf("expected about 1%% false positives, got %d in 10000", falsePositives)
+	}
+	if ok, _ := c.BFExists("missing", []byte("x")); ok {
+		t.Fatal("expected nothing to exist in a missing filter")
+	}
+
+	// BFAdd creates a default filter
+	c.BFAdd("g", []byte("x"), 0)
+	if ok, _ := c.BFExists("g", []byte("x")); !ok {
+		t.Fatal("expected x to exist in the default filter")
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.BFExists("plain", []byte("x")); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c := New(0)
+
+	// 10 per 100ms is one every 10ms, with bursts of 2 more
+	opts := RateLimitOptions{Rate: 10, Period: 100 * time.Millisecond, Burst: 2, Cost: 1}
+	for i := int64(2); i >= 0; i-- {
+		result, err := c.RateLimit("limit", opts)
+		if err != nil || !result.Allowed || result.Remaining != i {
+			t.Fatalf("expected an allowed request with %d remaining: %+v %v", i, result, err)
+		}
+	}
+	result, err := c.RateLimit("limit", opts)
+	if err != nil || result.Allowed || result.RetryAfter <= 0 || result.RetryAfter > 10*time.Millisecond {
+		t.Fatalf("expected a denied request with a retry after of up to 10ms: %+v %v", result, err)
+	}
+	time.Sleep(result.RetryAfter)
+	if result, _ = c.RateLimit("limit", opts); !result.Allowed {
+		t.Fatalf("expected an allowed request after waiting: %+v", result)
+	}
+
+	// a cost of 0 checks without using the limit
+	check := opts
+	check.Cost = 0
+	if result, _ = c.RateLimit("other", check); !result.Allowed || result.Remaining != 3 {
+		t.Fatalf("expected a full limit: %+v", result)
+	}
+	if _, err := c.Get("other"); err != ErrNotFound {
+		t.Fatal("expected checking not to store anything")
+	}
+
+	// the key expires once the limit is full again
+	c.RateLimit("expiring", opts)
+	time.Sleep(20 * time.Millisecond)
+	if _, err := c.Get("expiring"); err != ErrNotFound {
+		t.Fatal("expected the limit to expire once full")
+	}
+
+	big := opts
+	big.Cost = 4
+	if _, err := c.RateLimit("limit", big); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid for a cost over the burst: %v", err)
+	}
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.RateLimit("plain", opts); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	c := New(0)
+
+	owner, fence, err := c.AcquireLock("lock", time.Minute)
+	if err != nil || owner == "" || fence == 0 {
+		t.Fatalf("expected to acquire the lock: %q %d %v", owner, fence, err)
+	}
+	if _, _, err := c.AcquireLock("lock", time.Minute); err != ErrExists {
+		t.Fatalf("expected ErrExists for a held lock: %v", err)
+	}
+	if err := c.ReleaseLock("lock", "someone else"); err != ErrNotOwner {
+		t.Fatalf("expected ErrNotOwner releasing with the wrong owner: %v", err)
+	}
+	if _, err := c.RenewLock("lock", "someone else", time.Minute); err != ErrNotOwner {
+		t.Fatalf("expected ErrNotOwner renewing with the wrong owner: %v", err)
+	}
+	if f, err := c.RenewLock("lock", owner, time.Minute); err != nil || f != fence {
+		t.Fatalf("expected renewing to keep fence %d: %d %v", fence, f, err)
+	}
+	if err := c.ReleaseLock("lock", owner); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReleaseLock("lock", owner); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound releasing a released lock: %v", err)
+	}
+
+	// the lease runs out, and the next acquisition gets a larger fence
+	next, nextFence, err := c.AcquireLock("lock", 10*time.Millisecond)
+	if err != nil || next == owner || nextFence <= fence {
+		t.Fatalf("expected a new owner and a larger fence than %d: %q %d %v", fence, next, nextFence, err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := c.RenewLock("lock", next, time.Minute); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound renewing an expired lock: %v", err)
+	}
+	if _, f, err := c.AcquireLock("lock", time.Minute); err != nil || f <= nextFence {
+		t.Fatalf("expected to acquire the expired lock with a larger fence: %d %v", f, err)
+	}
+
+	if _, _, err := c.AcquireLock("forever", 0); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid without a lease: %v", err)
+	}
+	c.Set("plain", []byte("value"), 0)
+	if err := c.ReleaseLock("plain", owner); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+
+	// the plain value functions can't read the owner token, or change or
+	// remove the lock
+	if _, err := c.Get("lock"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType getting a lock: %v", err)
+	}
+	if _, _, err := c.Peek("lock"); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType peeking at a lock: %v", err)
+	}
+	if _, err := c.MetaGet("lock", MetaGetOptions{}); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType meta getting a lock: %v", err)
+	}
+	if _, err := c.Set("lock", []byte("stolen"), 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType setting a lock: %v", err)
+	}
+	if _, err := c.Touch("lock", 0, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType touching a lock: %v", err)
+	}
+	if err := c.Delete("lock", 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType deleting a lock: %v", err)
+	}
+	if err := c.MetaDelete("lock", MetaDeleteOptions{Invalidate: true}); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType invalidating a lock: %v", err)
+	}
+	if _, err := c.MetaSet("lock", []byte("stolen"), MetaSetOptions{}); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType meta setting a lock: %v", err)
+	}
+	if _, err := c.Append("lock", []byte("x"), 0, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType appending to a lock: %v", err)
+	}
+	c.FlushAll()
+	if _, err := c.Inspect("lock"); err != ErrNotFound {
+		t.Fatalf("expected FlushAll to remove the lock: %v", err)
+	}
+}
+
+func TestLockEviction(t *testing.T) {
+	c := New(2)
+	owner, _, err := c.AcquireLock("lock", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pressure from other items evicts around the held lock
+	for i := 0; i < 5; i++ {
+		c.Set(fmt.Sprintf("key:%d", i), []byte("value"), 0)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 items: %d", c.Len())
+	}
+	if _, _, err := c.AcquireLock("lock", time.Minute); err != ErrExists {
+		t.Fatalf("expected the held lock to survive eviction: %v", err)
+	}
+
+	// with only locks left, the cache stays over its limit rather than
+	// evicting one
+	if _, _, err := c.AcquireLock("other", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.AcquireLock("third", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 3 {
+		t.Fatalf("expected the three locks to be kept: %d", c.Len())
+	}
+	if err := c.ReleaseLock("lock", owner); err != nil {
+		t.Fatalf("expected the owner to still hold the lock: %v", err)
+	}
+
+	// an expired lock is evicted like anything else
+	c = New(1)
+	if _, _, err := c.AcquireLock("lock", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	c.Set("key", []byte("value"), 0)
+	if c.Len() != 1 {
+		t.Fatalf("expected the expired lock to be evicted: %d", c.Len())
+	}
+
+	c = New(0).WithMaxBytes(64)
+	if _, _, err := c.AcquireLock("lock", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	c.Set("big", make([]byte, 64), 0)
+	if _, _, err := c.AcquireLock("lock", time.Minute); err != ErrExists {
+		t.Fatalf("expected the held lock to survive maxBytes eviction: %v", err)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	c := New(0)
+
+	opts := ReceiveOptions{Visibility: 20 * time.Millisecond}
+	first, _ := c.Enqueue("q", []byte("first"), 0, 0)
+	c.Enqueue("q", []byte("second"), 0, 0)
+	c.Enqueue("q", []byte("delayed"), 30*time.Millisecond, 0)
+
+	m, err := c.Receive("q", opts)
+	if err != nil || m.ID != first || string(m.Body) != "first" || m.Receives != 1 {
+		t.Fatalf("expected the first message: %+v %v", m, err)
+	}
+	second, err := c.Receive("q", opts)
+	if err != nil || string(second.Body) != "second" {
+		t.Fatalf("expected the second message while the first is hidden: %+v %v", second, err)
+	}
+	if _, err := c.Receive("q", opts); err != ErrNotFound {
+		t.Fatalf("expected no visible messages: %v", err)
+	}
+	if err := c.Ack("q", second.Receipt); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Ack("q", second.Receipt); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound acking twice: %v", err)
+	}
+
+	// the first message wasn't acked, so it's delivered again, ahead of the
+	// delayed one
+	time.Sleep(30 * time.Millisecond)
+	again, err := c.Receive("q", opts)
+	if err != nil || again.ID != first || again.Receives != 2 || again.Receipt == m.Receipt {
+		t.Fatalf("expected the first message again with a new receipt: %+v %v", again, err)
+	}
+	if err := c.Ack("q", m.Receipt); err != ErrNotFound {
+		t.Fatalf("expected the old receipt not to ack the message: %v", err)
+	}
+	if m, _ = c.Receive("q", opts); string(m.Body) != "delayed" {
+		t.Fatalf("expected the delayed message: %+v", m)
+	}
+	if err := c.Nack("q", again.Receipt, 0); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ = c.Receive("q", opts); m.ID != first || m.Receives != 3 {
+		t.Fatalf("expected the nacked message right away: %+v", m)
+	}
+
+	// after three receives, the first message goes to the dead letter queue
+	time.Sleep(30 * time.Millisecond)
+	dlq := ReceiveOptions{MaxReceives: 3, DeadLetter: "dead"}
+	if m, _ = c.Receive("q", dlq); string(m.Body) != "delayed" {
+		t.Fatalf("expected the delayed message after dead lettering the first: %+v", m)
+	}
+	if m, _ = c.Receive("dead", opts); m.ID != first || m.Receives != 1 {
+		t.Fatalf("expected the first message in the dead letter queue: %+v", m)
+	}
+	c.Ack("dead", m.Receipt)
+	if _, err := c.Get("dead"); err != ErrNotFound {
+		t.Fatal("expected the empty dead letter queue to be deleted")
+	}
+
+	c.Set("plain", []byte("value"), 0)
+	if _, err := c.Enqueue("plain", []byte("x"), 0, 0); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain value: %v", err)
+	}
+
+	// a dead letter key that isn't a queue fails the receive rather than
+	// losing the message
+	id, _ := c.Enqueue("q2", []byte("poison"), 0, 0)
+	once := ReceiveOptions{Visibility: time.Millisecond, MaxReceives: 1, DeadLetter: "dead"}
+	c.Receive("q2", once)
+	time.Sleep(5 * time.Millisecond)
+	once.DeadLetter = "plain"
+	if _, err := c.Receive("q2", once); err != ErrWrongType {
+		t.Fatalf("expected ErrWrongType for a plain dead letter key: %v", err)
+	}
+	once.DeadLetter = "q2"
+	if _, err := c.Receive("q2", once); err != ErrInvalid {
+		t.Fatalf("expected ErrInvalid dead lettering a queue onto itself: %v", err)
+	}
+	once.DeadLetter = "dead"
+	c.Receive("q2", once)
+	if m, _ = c.Receive("dead", opts); m.ID != id {
+		t.Fatalf("expected the message to still be dead lettered: %+v", m)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	c := New(0).WithMaxBytes(1000)
+
+	c.Set("plain", bytes.Repeat([]byte("x"), 100), 0)
+	if c.Bytes() != 105 {
+		t.Fatalf("expected 105 bytes, got %d", c.Bytes())
+	}
+
+	// a set growing past the limit evicts the older item
+	for i := 0; i < 36; i++ {
+		c.SAdd("set", []string{fmt.Sprintf("member-%02d", i)}, 0)
+	}
+	if _, err := c.Get("plain"); err != ErrNotFound {
+		t.Fatal("expected 'plain' to be evicted by the growing set")
+	}
+	if c.Bytes() > 1000 {
+		t.Fatalf("cache grew past its limit: %d", c.Bytes())
+	}
+	info, _ := c.Inspect("set")
+	if int64(info.Size) != c.Bytes()-3 || info.Size == 0 {
+		t.Fatalf("expected the set to account for all but its key: %d of %d", info.Size, c.Bytes())
+	}
+
+	// removing members gives the bytes back
+	before := c.Bytes()
+	c.SRem("set", "member-00")
+	if c.Bytes() >= before {
+		t.Fatalf("expected SRem to reduce the size: %d >= %d", c.Bytes(), before)
+	}
+	for i := 1; i < 36; i++ {
+		c.SRem("set", fmt.Sprintf("member-%02d", i))
+	}
+	if c.Bytes() != 0 || c.Len() != 0 {
+		t.Fatalf("expected an empty cache: %d bytes, %d items", c.Bytes(), c.Len())
+	}
+
+	// an item too big on its own is kept until something else comes along
+	c.Set("huge", bytes.Repeat([]byte("x"), 2000), 0)
+	if _, err := c.Get("huge"); err != nil {
+		t.Fatal("expected 'huge' to be kept")
+	}
+	c.Set("small", []byte("x"), 0)
+	if _, err := c.Get("huge"); err != ErrNotFound {
+		t.Fatal("expected 'huge' to be evicted")
+	}
+
+	// arithmetic on a short varint grows the value to its full width
+	for name, update := range map[string]func(c *Cache) error{
+		"Increment": func(c *Cache) error {
+			_, _, err := c.Increment("b", 1)
+			return err
+		},
+		"Decrement": func(c *Cache) error {
+			_, _, err := c.Decrement("b", 0)
+			return err
+		},
+		"MetaArithmetic": func(c *Cache) error {
+			_, _, err := c.MetaArithmetic("b", MetaArithmeticOptions{Delta: 1})
+			return err
+		},
+	} {
+		c := New(0).WithMaxBytes(12)
+		c.Set("a", []byte{0}, 0)
+		c.Set("b", []byte{0}, 0)
+		if err := update(c); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, err := c.Get("a"); err != ErrNotFound || c.Bytes() > 12 {
+			t.Fatalf("%s: expected 'a' to be evicted by the grown value: %v, %d bytes", name, err, c.Bytes())
+		}
+	}
+}
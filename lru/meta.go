@@ -0,0 +1,294 @@
+package lru
+
+import "time"
+
+// The Meta* functions follow the semantics of memcached's meta commands (mg,
+// ms, md and ma), where a single command's behavior is selected by flags. On
+// top of the plain operations they offer autovivification on miss, stale
+// while revalidate style invalidation, and "win" tokens so that only one of
+// many clients racing on a missing or stale item recaches it:
+//
+// A MetaGet with Vivify on a miss creates an empty placeholder item and
+// returns Win to that client only; everyone else sees the placeholder along
+// with WinSent and can back off or wait until the winner sets the real value.
+// A MetaDelete with Invalidate marks the item stale rather than removing it;
+// the next MetaGet gets the stale value with Stale and Win set, and following
+// MetaGets get the stale value with WinSent until it's replaced. A MetaGet
+// with RecacheTTL hands out Win when the item's remaining TTL drops below it,
+// so it can be refreshed before it expires.
+
+// MetaGetOptions are the flags for MetaGet.
+type MetaGetOptions struct {
+	// NoBump leaves the item's LRU position and last access time alone.
+	NoBump bool
+	// Touch updates the item's TTL to TTL.
+	Touch bool
+	TTL   time.Duration
+	// Vivify creates an empty item with VivifyTTL on a miss and returns Win.
+	Vivify    bool
+	VivifyTTL time.Duration
+	// RecacheTTL returns Win if the item's remaining TTL is less than it.
+	// Zero disables it.
+	RecacheTTL time.Duration
+}
+
+// MetaResult is the item data returned by MetaGet.
+type MetaResult struct {
+	Value []byte
+	CAS   uint64
+	// TTL is the remaining time to live, or 0 if the item doesn't expire.
+	TTL  time.Duration
+	Size int
+	// HitBefore is true if the item had been read before this call.
+	HitBefore bool
+	// LastAccess is the time since the item was last accessed, prior to this
+	// call.
+	LastAccess time.Duration
+	// Win is true if this client should recache the item.
+	Win bool
+	// Vivified is true if the item was missing and Vivify created it. Win is
+	// always set along with it.
+	Vivified bool
+	// Stale is true if the item has been invalidated.
+	Stale bool
+	// WinSent is true if another client has already been sent Win.
+	WinSent bool
+}
+
+// MetaSetMode selects the kind of store MetaSet does.
+type MetaSetMode int
+
+const (
+	// MetaSetModeSet unconditionally stores the item, like Set.
+	MetaSetModeSet MetaSetMode = iota
+	// MetaSetModeAdd stores the item only if it doesn't exist, like Add.
+	MetaSetModeAdd
+	// MetaSetModeReplace stores the item only if it exists, like Replace.
+	MetaSetModeReplace
+	// MetaSetModeAppend appends to the existing value, like Append.
+	MetaSetModeAppend
+	// MetaSetModePrepend prepends to the existing value, like Prepend.
+	MetaSetModePrepend
+)
+
+// MetaSetOptions are the flags for MetaSet.
+type MetaSetOptions struct {
+	Mode MetaSetMode
+	TTL  time.Duration
+	// CAS, if not zero, must match the item's current CAS.
+	CAS uint64
+	// Invalidate, along with CAS, stores the item even if CAS is older than
+	// the item's current CAS, but marks it stale.
+	Invalidate bool
+	// Vivify creates the item on a miss in append and prepend modes.
+	Vivify bool
+}
+
+// MetaDeleteOptions are the flags for MetaDelete.
+type MetaDeleteOptions struct {
+	// CAS, if not zero, must match the item's current CAS.
+	CAS uint64
+	// Invalidate marks the item stale instead of removing it. If TTL is not
+	// zero, the stale item's TTL is updated to it.
+	Invalidate bool
+	TTL        time.Duration
+}
+
+// MetaArithmeticOptions are the flags for MetaArithmetic.
+type MetaArithmeticOptions struct {
+	// Decrement decrements rather than increments. Unlike Decrement, the
+	// value won't wrap around below zero.
+	Decrement bool
+	Delta     uint64
+	// CAS, if not zero, must match the item's current CAS.
+	CAS uint64
+	// Touch updates the item's TTL to TTL.
+	Touch bool
+	TTL   time.Duration
+	// Vivify creates the item with the value Initial and VivifyTTL on a miss.
+	Vivify    bool
+	Initial   uint64
+	VivifyTTL time.Duration
+}
+
+// MetaGet gets the item along with its metadata. See MetaGetOptions for the
+// available flags.
+func (c *Cache) MetaGet(key string, opts MetaGetOptions) (MetaResult, error) {
+	i := c.lookupLive(key)
+	if i == nilIndex {
+		if !opts.Vivify {
+			return MetaResult{}, ErrNotFound
+		}
+		cas := c.nextCasID()
+		i = c.insert(key, nil, opts.VivifyTTL, cas)
+		c.entries[i].flags |= entryWinSent
+		c.evictOverflow()
+		return MetaResult{CAS: cas, TTL: opts.VivifyTTL, Win: true, Vivified: true}, nil
+	}
+
+	if c.isObject(i) {
+		return MetaResult{}, ErrWrongType
+	}
+	e := &c.entries[i]
+	now := time.Now().UnixNano()
+	result := MetaResult{
+		Value:      c.copyValue(e),
+		CAS:        e.cas,
+		Size:       int(e.valueLen),
+		HitBefore:  e.flags&entryFetched != 0,
+		LastAccess: time.Duration(now - e.accessedAt),
+		Stale:      e.flags&entryStale != 0,
+	}
+	if opts.Touch {
+		e.ttl = opts.TTL
+		e.createdAt = now
+	}
+	if e.ttl != 0 {
+		result.TTL = e.ttl - time.Duration(now-e.createdAt)
+	}
+
+	recache := result.Stale || (opts.RecacheTTL != 0 && e.ttl != 0 && result.TTL < opts.RecacheTTL)
+	if e.flags&entryWinSent != 0 {
+		result.WinSent = true
+	} else if recache {
+		result.Win = true
+		e.flags |= entryWinSent
+	}
+
+	e.flags |= entryFetched
+	if !opts.NoBump {
+		c.moveToFront(i)
+		e.accessedAt = now
+	}
+	return result, nil
+}
+
+// MetaSet stores the item and returns its new CAS. See MetaSetOptions for the
+// available flags.
+func (c *Cache) MetaSet(key string, value []byte, opts MetaSetOptions) (uint64, error) {
+	i := c.lookupLive(key)
+
+	stale := false
+	if opts.CAS != 0 {
+		if i == nilIndex {
+			return 0, ErrNotFound
+		}
+		if current := c.entries[i].cas; opts.CAS != current {
+			if !opts.Invalidate || opts.CAS > current {
+				return 0, ErrExists
+			}
+			stale = true
+		}
+	}
+
+	switch opts.Mode {
+	case MetaSetModeAdd:
+		if i != nilIndex {
+			return 0, ErrExists
+		}
+	case MetaSetModeReplace:
+		if i == nilIndex {
+			return 0, ErrNotFound
+		}
+	case MetaSetModeAppend, MetaSetModePrepend:
+		if i == nilIndex {
+			if !opts.Vivify {
+				return 0, ErrNotFound
+			}
+			break
+		}
+		if c.isObject(i) {
+			return 0, ErrWrongType
+		}
+		if opts.Mode == MetaSetModeAppend {
+			value = append(c.copyValue(&c.entries[i]), value...)
+		} else {
+			value = append(append([]byte{}, value...), c.value(&c.entries[i])...)
+		}
+	}
+
+	if _, err := c.Set(key, value, opts.TTL); err != nil {
+		return 0, err
+	}
+	e := &c.entries[c.find(key)]
+	if stale {
+		e.flags |= entryStale
+	}
+	return e.cas, nil
+}
+
+// MetaDelete deletes (or invalidates) the item. See MetaDeleteOptions for the
+// available flags.
+func (c *Cache) MetaDelete(key string, opts MetaDeleteOptions) error {
+	i := c.lookupLive(key)
+	if i == nilIndex {
+		return ErrNotFound
+	}
+	if c.isLock(i) {
+		return ErrWrongType
+	}
+	e := &c.entries[i]
+	if opts.CAS != 0 && opts.CAS != e.cas {
+		return ErrExists
+	}
+	if opts.Invalidate {
+		e.flags = (e.flags | entryStale) &^ entryWinSent
+		e.cas = c.nextCasID()
+		if opts.TTL != 0 {
+			e.ttl = opts.TTL
+			e.createdAt = time.Now().UnixNano()
+		}
+		return nil
+	}
+	return c.Delete(key, 0)
+}
+
+// MetaArithmetic increments or decrements a counter, returning the new value
+// and CAS. See MetaArithmeticOptions for the available flags.
+func (c *Cache) MetaArithmetic(key string, opts MetaArithmeticOptions) (uint64, uint64, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !opts.Vivify {
+			return 0, 0, ErrNotFound
+		}
+		cas, err := c.Set(key, Uint64ToBytes(opts.Initial), opts.VivifyTTL)
+		return opts.Initial, cas, err
+	}
+	if c.isObject(i) {
+		return 0, 0, ErrWrongType
+	}
+	if opts.CAS != 0 && opts.CAS != c.entries[i].cas {
+		return 0, 0, ErrExists
+	}
+	n, err := BytesToUint64(c.value(&c.entries[i]))
+	if err != nil {
+		return 0, 0, err
+	}
+	if !opts.Decrement {
+		n += opts.Delta
+	} else if opts.Delta > n {
+		n = 0
+	} else {
+		n -= opts.Delta
+	}
+	c.setValue(i, Uint64ToBytes(n))
+	e := &c.entries[i]
+	e.cas = c.nextCasID()
+	if opts.Touch {
+		e.ttl = opts.TTL
+		e.createdAt = time.Now().UnixNano()
+	}
+	cas := e.cas
+	c.evictOverflow()
+	return n, cas, nil
+}
+
+// lookupLive is lookup, but evicts and ignores an expired item.
+func (c *Cache) lookupLive(key string) int32 {
+	i := c.lookup(key)
+	if i != nilIndex && isExpired(&c.entries[i]) {
+		c.evict(i, TTLEviction)
+		return nilIndex
+	}
+	return i
+}
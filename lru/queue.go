@@ -0,0 +1,236 @@
+package lru
+
+import "time"
+
+// DefaultVisibility is how long a received message stays hidden when
+// ReceiveOptions doesn't say.
+const DefaultVisibility = 30 * time.Second
+
+// queueMessageOverhead is an estimate of the bookkeeping for each message, on
+// top of its body.
+const queueMessageOverhead = 48
+
+// QueueMessage is a message handed out by Receive.
+type QueueMessage struct {
+	// ID identifies the message. It's assigned by Enqueue and never changes.
+	ID   uint64
+	Body []byte
+	// Receipt identifies this receipt of the message, for Ack and Nack. Each
+	// Receive of a message gets a new one, so a consumer whose visibility
+	// timeout ran out can't ack the message out from under the next one.
+	Receipt uint64
+	// Receives is the number of times the message has been received,
+	// including this one.
+	Receives int
+}
+
+type queueMessage struct {
+	QueueMessage
+	// visibleAt is when the message can next be received, in Unix nanoseconds
+	visibleAt int64
+}
+
+// queue is a queue value. Messages are kept in the order they were enqueued,
+// and Receive hands out the first visible one, so delayed and redelivered
+// messages keep their place. As with list, this is fine for the short queues
+// a cache is meant for. Like TTLs, visibility timeouts are lazy: a message
+// just becomes eligible again when its time has passed.
+type queue struct {
+	messages []*queueMessage
+	bytes    int
+}
+
+func (q *queue) size() int {
+	return q.bytes
+}
+
+// find returns the index of the message with receipt, or -1.
+func (q *queue) find(receipt uint64) int {
+	if receipt == 0 {
+		return -1
+	}
+	for j, m := range q.messages {
+		if m.Receipt == receipt {
+			return j
+		}
+	}
+	return -1
+}
+
+// remove removes the message at index j.
+func (q *queue) remove(j int) *queueMessage {
+	m := q.messages[j]
+	copy(q.messages[j:], q.messages[j+1:])
+	q.messages[len(q.messages)-1] = nil
+	q.messages = q.messages[:len(q.messages)-1]
+	q.bytes -= len(m.Body) + queueMessageOverhead
+	return m
+}
+
+// push appends a message.
+func (q *queue) push(m *queueMessage) {
+	q.messages = append(q.messages, m)
+	q.bytes += len(m.Body) + queueMessageOverhead
+}
+
+// getQueue returns the queue stored at key and its entry index. With create,
+// a missing key gets a new, empty queue. A key holding something other than a
+// queue is ErrWrongType.
+func (c *Cache) getQueue(key string, create bool) (*queue, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !create {
+			return nil, nilIndex, ErrNotFound
+		}
+		q := &queue{}
+		return q, c.insertObject(key, q), nil
+	}
+	q, ok := c.objects[i].(*queue)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return q, i, nil
+}
+
+// Enqueue adds a message to the queue stored at key, creating the queue if it
+// doesn't exist, and returns the message's ID. The message can't be received
+// until delay has passed. If ttl is not zero, the TTL of the whole queue is
+// set to it.
+func (c *Cache) Enqueue(key string, body []byte, delay time.Duration, ttl time.Duration) (uint64, error) {
+	q, i, err := c.getQueue(key, true)
+	if err != nil {
+		return 0, err
+	}
+	m := &queueMessage{
+		QueueMessage: QueueMessage{ID: c.nextCasID(), Body: append([]byte(nil), body...)},
+		visibleAt:    time.Now().Add(delay).UnixNano(),
+	}
+	q.push(m)
+	c.valueUpdated(i, ttl)
+	return m.ID, nil
+}
+
+// ReceiveOptions configures Receive.
+type ReceiveOptions struct {
+	// Visibility is how long the received message is hidden from other
+	// receives. If it isn't acked in that time, it's delivered again.
+	// DefaultVisibility is used if it's 0.
+	Visibility time.Duration
+	// MaxReceives, if not 0, is the number of times a message is delivered
+	// before it's given up on: the next time it would be delivered, it's
+	// moved to the DeadLetter queue instead, or dropped if there isn't one.
+	// DeadLetter can't be the queue itself.
+	MaxReceives int
+	DeadLetter  string
+}
+
+// Receive hands out the first visible message in the queue stored at key and
+// hides it for the visibility timeout. It returns ErrNotFound if the queue
+// doesn't exist or has no visible messages. If opts.DeadLetter holds
+// something other than a queue, it returns ErrWrongType without receiving or
+// dead-lettering anything, and if it's key, with MaxReceives set, ErrInvalid.
+func (c *Cache) Receive(key string, opts ReceiveOptions) (QueueMessage, error) {
+	if opts.Visibility == 0 {
+		opts.Visibility = DefaultVisibility
+	}
+	deadLetter := opts.MaxReceives != 0 && opts.DeadLetter != ""
+	if deadLetter && opts.DeadLetter == key {
+		// the messages would be dead lettered onto the queue they're being
+		// taken off, and lost
+		return QueueMessage{}, ErrInvalid
+	}
+	if deadLetter {
+		// check before any messages are taken off the queue, so they can't
+		// be lost
+		if _, _, err := c.getQueue(opts.DeadLetter, false); err != nil && err != ErrNotFound {
+			return QueueMessage{}, err
+		}
+	}
+	q, i, err := c.getQueue(key, false)
+	if err != nil {
+		return QueueMessage{}, err
+	}
+	now := time.Now().UnixNano()
+	var dead []*queueMessage
+	var received *queueMessage
+	for j := 0; j < len(q.messages); {
+		m := q.messages[j]
+		if m.visibleAt > now {
+			j++
+			continue
+		}
+		if opts.MaxReceives != 0 && m.Receives >= opts.MaxReceives {
+			dead = append(dead, q.remove(j))
+			continue
+		}
+		m.Receives++
+		m.Receipt = c.nextCasID()
+		m.visibleAt = now + int64(opts.Visibility)
+		received = m
+		break
+	}
+
+	if len(q.messages) == 0 {
+		c.removeEntry(i)
+	} else if received != nil || len(dead) != 0 {
+		c.valueUpdated(i, 0)
+	}
+	if len(dead) != 0 && deadLetter {
+		if dlq, k, err := c.getQueue(opts.DeadLetter, true); err == nil {
+			for _, m := range dead {
+				m.Receipt = 0
+				m.Receives = 0
+				m.visibleAt = now
+				dlq.push(m)
+			}
+			c.valueUpdated(k, 0)
+		}
+	}
+
+	if received == nil {
+		return QueueMessage{}, ErrNotFound
+	}
+	message := received.QueueMessage
+	message.Body = append([]byte(nil), message.Body...)
+	return message, nil
+}
+
+// Ack deletes the message with receipt from the queue stored at key, once
+// it's been handled. A missing message or receipt, including one superseded
+// by a later Receive, is ErrNotFound. A queue left empty is deleted.
+func (c *Cache) Ack(key string, receipt uint64) error {
+	q, i, err := c.getQueue(key, false)
+	if err != nil {
+		return err
+	}
+	j := q.find(receipt)
+	if j < 0 {
+		return ErrNotFound
+	}
+	q.remove(j)
+	if len(q.messages) == 0 {
+		c.removeEntry(i)
+	} else {
+		c.valueUpdated(i, 0)
+	}
+	return nil
+}
+
+// Nack gives up on the message with receipt, making it visible again after
+// delay rather than when its visibility timeout runs out. Errors are as for
+// Ack.
+func (c *Cache) Nack(key string, receipt uint64, delay time.Duration) error {
+	q, i, err := c.getQueue(key, false)
+	if err != nil {
+		return err
+	}
+	j := q.find(receipt)
+	if j < 0 {
+		return ErrNotFound
+	}
+	m := q.messages[j]
+	m.Receipt = 0
+	m.visibleAt = time.Now().Add(delay).UnixNano()
+	c.valueUpdated(i, 0)
+	return nil
+}
@@ -0,0 +1,77 @@
+package lru
+
+// maxRangeValue bounds the values SetRange can create, so a large offset
+// can't allocate an arbitrary amount of zero padding.
+const maxRangeValue = 512 << 20
+
+// GetRange returns the bytes of the value stored at key from start to stop,
+// inclusive, with indexes as in LRange, so a client can read part of a large
+// value, like the tail of a log built with Append. A missing key is an empty
+// value.
+func (c *Cache) GetRange(key string, start, stop int) ([]byte, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		return nil, nil
+	}
+	if c.isObject(i) {
+		return nil, ErrWrongType
+	}
+	value := c.value(&c.entries[i])
+	start, stop = listRange(len(value), start, stop)
+	return append([]byte(nil), value[start:stop]...), nil
+}
+
+// SetRange overwrites the value stored at key with value, starting at offset.
+// A value too short to reach offset is padded with zero bytes, and a missing
+// key is a value of zero bytes. It returns the length and CAS ID of the
+// resulting value. Writes within the current value are done in place, without
+// copying it. A negative offset, or one that would make the value larger than
+// 512MB, is ErrInvalid.
+func (c *Cache) SetRange(key string, offset int, value []byte) (int, uint64, error) {
+	if offset < 0 || offset > maxRangeValue || len(value) > maxRangeValue-offset {
+		return 0, 0, ErrInvalid
+	}
+	i := c.getElement(key)
+	if i == nilIndex {
+		if len(value) == 0 {
+			return 0, 0, nil
+		}
+		newValue := make([]byte, offset+len(value))
+		copy(newValue[offset:], value)
+		cas, err := c.Set(key, newValue, 0)
+		return len(newValue), cas, err
+	}
+	if c.isObject(i) {
+		return 0, 0, ErrWrongType
+	}
+	e := &c.entries[i]
+	if len(value) == 0 {
+		return int(e.valueLen), e.cas, nil
+	}
+	if end := offset + len(value); end <= int(e.valueLen) {
+		copy(c.value(e)[offset:], value)
+		// a new value, as far as the meta flags go, as setValue would do
+		e.flags = 0
+	} else {
+		newValue := make([]byte, end)
+		copy(newValue, c.value(e))
+		copy(newValue[offset:], value)
+		c.setValue(i, newValue)
+	}
+	c.valueUpdated(i, 0)
+	e = &c.entries[i]
+	return int(e.valueLen), e.cas, nil
+}
+
+// StrLen returns the length of the value stored at key, or 0 if it doesn't
+// exist.
+func (c *Cache) StrLen(key string) (int, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		return 0, nil
+	}
+	if c.isObject(i) {
+		return 0, ErrWrongType
+	}
+	return int(c.entries[i].valueLen), nil
+}
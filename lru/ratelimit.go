@@ -0,0 +1,94 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+)
+
+// Rate limits use the generic cell rate algorithm (GCRA), which is equivalent
+// to a token bucket that refills continuously but only needs to store one
+// number per key: the theoretical arrival time (TAT), when the bucket will be
+// full again. It's stored as a plain value, rateLimitMagic followed by the TAT
+// in Unix nanoseconds, that expires when the bucket is full, so idle keys
+// don't take up space.
+
+const rateLimitMagic = "GCRA"
+
+// RateLimitOptions configures a rate limit: Rate requests per Period, with
+// bursts of up to Burst requests beyond that.
+type RateLimitOptions struct {
+	Rate   int64
+	Period time.Duration
+	Burst  int64
+	// Cost is how much of the limit this request uses, usually 1. A cost of 0
+	// checks the limit without using any of it.
+	Cost int64
+}
+
+// RateLimitResult is the outcome of a RateLimit call.
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining is the number of requests (of cost 1) that would be allowed
+	// right now.
+	Remaining int64
+	// RetryAfter is how long to wait before the request would be allowed. It's
+	// 0 if the request was allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the limit is back to its full burst.
+	ResetAfter time.Duration
+}
+
+// RateLimit checks a request against the rate limit stored at key, using up
+// opts.Cost of it if the request is allowed. A missing key is an unused
+// limit. It returns ErrInvalid if the options don't make sense, including a
+// cost that could never be allowed, and ErrWrongType if key holds something
+// other than a rate limit.
+func (c *Cache) RateLimit(key string, opts RateLimitOptions) (RateLimitResult, error) {
+	if opts.Rate <= 0 || opts.Period <= 0 || opts.Burst < 0 || opts.Cost < 0 || opts.Cost > opts.Burst+1 {
+		return RateLimitResult{}, ErrInvalid
+	}
+	emission := opts.Period / time.Duration(opts.Rate)
+	if emission == 0 {
+		return RateLimitResult{}, ErrInvalid
+	}
+	limit := emission * time.Duration(opts.Burst+1)
+	now := time.Now().UnixNano()
+
+	tat := now
+	if i := c.getElement(key); i != nilIndex {
+		if c.isObject(i) {
+			return RateLimitResult{}, ErrWrongType
+		}
+		value := c.value(&c.entries[i])
+		if len(value) != len(rateLimitMagic)+8 || !bytes.HasPrefix(value, []byte(rateLimitMagic)) {
+			return RateLimitResult{}, ErrWrongType
+		}
+		if stored := int64(binary.BigEndian.Uint64(value[len(rateLimitMagic):])); stored > now {
+			tat = stored
+		}
+	}
+
+	newTat := tat + int64(emission)*opts.Cost
+	allowAt := newTat - int64(limit)
+	if now < allowAt {
+		used := time.Duration(tat - now)
+		return RateLimitResult{
+			Remaining:  int64((limit - used) / emission),
+			RetryAfter: time.Duration(allowAt - now),
+			ResetAfter: used,
+		}, nil
+	}
+	used := time.Duration(newTat - now)
+	if opts.Cost != 0 {
+		value := make([]byte, len(rateLimitMagic)+8)
+		copy(value, rateLimitMagic)
+		binary.BigEndian.PutUint64(value[len(rateLimitMagic):], uint64(newTat))
+		c.Set(key, value, used)
+	}
+	return RateLimitResult{
+		Allowed:    true,
+		Remaining:  int64((limit - used) / emission),
+		ResetAfter: used,
+	}, nil
+}
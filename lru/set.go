@@ -0,0 +1,213 @@
+package lru
+
+import (
+	"sort"
+	"time"
+)
+
+// set is a set value: an unordered collection of unique string members.
+type set struct {
+	members map[string]struct{}
+	bytes   int
+}
+
+func (s *set) size() int {
+	return s.bytes
+}
+
+// getSet returns the set stored at key and its entry index. With create, a
+// missing key gets a new, empty set. A key holding something other than a set
+// is ErrWrongType.
+func (c *Cache) getSet(key string, create bool) (*set, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !create {
+			return nil, nilIndex, ErrNotFound
+		}
+		s := &set{members: make(map[string]struct{})}
+		return s, c.insertObject(key, s), nil
+	}
+	s, ok := c.objects[i].(*set)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return s, i, nil
+}
+
+// SAdd adds members to the set stored at key, creating the set if it doesn't
+// exist, and returns the number that weren't already members. If ttl is not
+// zero, the TTL of the whole set is set to it.
+func (c *Cache) SAdd(key string, members []string, ttl time.Duration) (int, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	s, i, err := c.getSet(key, true)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, member := range members {
+		if _, ok := s.members[member]; !ok {
+			s.members[member] = struct{}{}
+			s.bytes += len(member) + elementOverhead
+			added++
+		}
+	}
+	c.valueUpdated(i, ttl)
+	return added, nil
+}
+
+// SRem removes members from the set stored at key and returns the number that
+// were members. A set left empty is deleted.
+func (c *Cache) SRem(key string, members ...string) (int, error) {
+	s, i, err := c.getSet(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, member := range members {
+		if _, ok := s.members[member]; ok {
+			delete(s.members, member)
+			s.bytes -= len(member) + elementOverhead
+			removed++
+		}
+	}
+	if len(s.members) == 0 {
+		c.removeEntry(i)
+		return removed, nil
+	}
+	if removed != 0 {
+		c.valueUpdated(i, 0)
+	}
+	return removed, nil
+}
+
+// SIsMember returns true if member is in the set stored at key.
+func (c *Cache) SIsMember(key string, member string) (bool, error) {
+	s, _, err := c.getSet(key, false)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, ok := s.members[member]
+	return ok, nil
+}
+
+// SMembers returns the members of the set stored at key, sorted. A missing
+// key is an empty set.
+func (c *Cache) SMembers(key string) ([]string, error) {
+	s, _, err := c.getSet(key, false)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sortedMembers(s.members), nil
+}
+
+// SCard returns the number of members in the set stored at key, or 0 if it
+// doesn't exist.
+func (c *Cache) SCard(key string) (int, error) {
+	s, _, err := c.getSet(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(s.members), nil
+}
+
+// SInter returns the members of the intersection of the sets stored at keys,
+// sorted. Missing keys are empty sets.
+func (c *Cache) SInter(keys ...string) ([]string, error) {
+	sets, err := c.getSets(keys)
+	if err != nil || len(sets) == 0 {
+		return nil, err
+	}
+	result := make(map[string]struct{})
+	for member := range sets[0] {
+		in := true
+		for _, other := range sets[1:] {
+			if _, ok := other[member]; !ok {
+				in = false
+				break
+			}
+		}
+		if in {
+			result[member] = struct{}{}
+		}
+	}
+	return sortedMembers(result), nil
+}
+
+// SUnion returns the members of the union of the sets stored at keys, sorted.
+// Missing keys are empty sets.
+func (c *Cache) SUnion(keys ...string) ([]string, error) {
+	sets, err := c.getSets(keys)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]struct{})
+	for _, members := range sets {
+		for member := range members {
+			result[member] = struct{}{}
+		}
+	}
+	return sortedMembers(result), nil
+}
+
+// SDiff returns the members of the set stored at the first key that aren't in
+// any of the sets stored at the rest, sorted. Missing keys are empty sets.
+func (c *Cache) SDiff(keys ...string) ([]string, error) {
+	sets, err := c.getSets(keys)
+	if err != nil || len(sets) == 0 {
+		return nil, err
+	}
+	result := make(map[string]struct{})
+	for member := range sets[0] {
+		in := false
+		for _, other := range sets[1:] {
+			if _, ok := other[member]; ok {
+				in = true
+				break
+			}
+		}
+		if !in {
+			result[member] = struct{}{}
+		}
+	}
+	return sortedMembers(result), nil
+}
+
+// getSets returns the members of the sets stored at keys, with missing keys
+// as empty sets. Any key holding something other than a set is ErrWrongType.
+func (c *Cache) getSets(keys []string) ([]map[string]struct{}, error) {
+	sets := make([]map[string]struct{}, len(keys))
+	for j, key := range keys {
+		s, _, err := c.getSet(key, false)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sets[j] = s.members
+	}
+	return sets, nil
+}
+
+func sortedMembers(members map[string]struct{}) []string {
+	sorted := make([]string, 0, len(members))
+	for member := range members {
+		sorted = append(sorted, member)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
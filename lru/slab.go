@@ -0,0 +1,179 @@
+package lru
+
+// Item data (key followed by value) is stored in slabs, following the design
+// of memcached's slab allocator. Memory is carved out of the heap in large
+// pages, each page belongs to a size class and is split into equally sized
+// chunks, and an item is stored in a chunk of the smallest class it fits in.
+// Chunks are addressed by index rather than by pointer, so the only pointers
+// the garbage collector has to follow are the ones to the pages themselves,
+// no matter how many items are cached.
+//
+// Pages are never returned to the heap (except for items too large for any
+// class, which get a page of their own). Freed chunks go on a per class free
+// list and are reused by the next allocation in that class.
+
+const (
+	// slabPageSize is the size of each page. It also bounds the largest
+	// chunk size; anything bigger gets a dedicated page.
+	slabPageSize = 1 << 20
+	// slabMinChunk is the chunk size of the smallest class.
+	slabMinChunk = 64
+	// slabGrowthFactor is the ratio between chunk sizes of consecutive
+	// classes.
+	slabGrowthFactor = 1.25
+	// slabAlign is the alignment of chunk sizes.
+	slabAlign = 8
+)
+
+// slabRef is the location of a chunk: the index of its page in the page table,
+// and its class and byte offset within the page packed into pos, with the
+// class in the top byte. Keeping the offset rather than the chunk's index in
+// its class means finding the chunk doesn't take a division, and packing it
+// with the class keeps the ref, and so each entry, small.
+type slabRef struct {
+	page uint32
+	pos  uint32
+}
+
+// slabOffsetBits is the number of bits of pos holding the offset. Pages are
+// at most slabPageSize, so offsets always fit.
+const slabOffsetBits = 24
+
+func newSlabRef(class, page, offset int) slabRef {
+	return slabRef{uint32(page), uint32(class)<<slabOffsetBits | uint32(offset)}
+}
+
+func (r slabRef) class() int {
+	return int(r.pos >> slabOffsetBits)
+}
+
+func (r slabRef) offset() int {
+	return int(r.pos & (1<<slabOffsetBits - 1))
+}
+
+// slabClass is a set of pages split into chunks of the same size. Its pages
+// are listed by their index in the page table.
+type slabClass struct {
+	size      int
+	perPage   int
+	pages     []uint32
+	free      []slabRef
+	allocated int
+}
+
+// slabs is the allocator. The last class is special: it holds items too large
+// for any other class, one per page, with each page sized to fit.
+//
+// The pages of all the classes are kept in a single table, so finding a chunk
+// from its ref takes one lookup rather than one for the class and another for
+// the page.
+type slabs struct {
+	classes []slabClass
+	pages   [][]byte
+}
+
+// newSlabs sets up the size classes. No memory is allocated until it's needed.
+func newSlabs() slabs {
+	var s slabs
+	size := slabMinChunk
+	for size < slabPageSize/2 {
+		s.classes = append(s.classes, slabClass{size: size, perPage: slabPageSize / size})
+		size = int(float64(size) * slabGrowthFactor)
+		if rem := size % slabAlign; rem != 0 {
+			size += slabAlign - rem
+		}
+	}
+	s.classes = append(s.classes, slabClass{size: slabPageSize, perPage: 1})
+	s.classes = append(s.classes, slabClass{perPage: 1})
+	return s
+}
+
+// classFor returns the index of the smallest class with chunks of at least n
+// bytes.
+func (s *slabs) classFor(n int) int {
+	huge := len(s.classes) - 1
+	if n > s.classes[huge-1].size {
+		return huge
+	}
+	lo, hi := 0, huge-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if s.classes[mid].size >= n {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// alloc reserves a chunk of at least n bytes.
+func (s *slabs) alloc(n int) slabRef {
+	class := s.classFor(n)
+	sc := &s.classes[class]
+	if class == len(s.classes)-1 {
+		// too big for any class: a page of its own
+		page := make([]byte, n)
+		if l := len(sc.free); l > 0 {
+			ref := sc.free[l-1]
+			sc.free = sc.free[:l-1]
+			s.pages[ref.page] = page
+			return ref
+		}
+		return newSlabRef(class, s.addPage(page), 0)
+	}
+	if l := len(sc.free); l > 0 {
+		ref := sc.free[l-1]
+		sc.free = sc.free[:l-1]
+		return ref
+	}
+	if sc.allocated == len(sc.pages)*sc.perPage {
+		sc.pages = append(sc.pages, uint32(s.addPage(make([]byte, sc.perPage*sc.size))))
+	}
+	chunk := sc.allocated
+	sc.allocated++
+	return newSlabRef(class, int(sc.pages[chunk/sc.perPage]), chunk%sc.perPage*sc.size)
+}
+
+// addPage adds the page to the page table and returns its index.
+func (s *slabs) addPage(page []byte) int {
+	s.pages = append(s.pages, page)
+	return len(s.pages) - 1
+}
+
+// release returns the chunk to its class's free list.
+func (s *slabs) release(ref slabRef) {
+	sc := &s.classes[ref.class()]
+	if ref.class() == len(s.classes)-1 {
+		s.pages[ref.page] = nil
+	}
+	sc.free = append(sc.free, ref)
+}
+
+// chunk returns the memory for the chunk. The returned slice aliases the slab
+// so must not be held on to past the next release of ref.
+func (s *slabs) chunk(ref slabRef) []byte {
+	page := s.pages[ref.page]
+	class := ref.class()
+	if class == len(s.classes)-1 {
+		return page
+	}
+	offset := ref.offset()
+	size := s.classes[class].size
+	return page[offset : offset+size : offset+size]
+}
+
+// data returns the memory from the start of the chunk to the end of its page,
+// for reads that are bounded by lengths of their own, like an entry's key and
+// value. It's cheaper than chunk, which matters on the read path.
+func (s *slabs) data(ref slabRef) []byte {
+	return s.pages[ref.page][ref.offset():]
+}
+
+// capacity returns the usable size of the chunk.
+func (s *slabs) capacity(ref slabRef) int {
+	if class := ref.class(); class == len(s.classes)-1 {
+		return len(s.pages[ref.page])
+	}
+	return s.classes[ref.class()].size
+}
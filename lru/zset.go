@@ -0,0 +1,414 @@
+package lru
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ScoredMember is a member of a sorted set and its score.
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// The sorted set follows Redis's design: a map from member to score for
+// lookups, plus a skiplist ordered by (score, member) for ranges. Each
+// skiplist link records how many nodes it spans, so a member's rank and the
+// member at a given rank can be found in O(log n) too.
+
+const (
+	// zsetMaxLevel caps the height of the skiplist, which is plenty for 2^32
+	// members with zsetP.
+	zsetMaxLevel = 32
+	// zsetP is the chance of a node having each additional level.
+	zsetP = 0.25
+)
+
+type zsetLevel struct {
+	forward *zsetNode
+	// span is the number of nodes between this one and forward
+	span int
+}
+
+type zsetNode struct {
+	member   string
+	score    float64
+	backward *zsetNode
+	level    []zsetLevel
+}
+
+// zset is a sorted set value.
+type zset struct {
+	dict   map[string]float64
+	head   *zsetNode
+	tail   *zsetNode
+	length int
+	level  int
+	bytes  int
+}
+
+func newZset() *zset {
+	return &zset{
+		dict:  make(map[string]float64),
+		head:  &zsetNode{level: make([]zsetLevel, zsetMaxLevel)},
+		level: 1,
+	}
+}
+
+func (z *zset) size() int {
+	return z.bytes
+}
+
+// zsetLess orders nodes by score, then member.
+func zsetLess(n *zsetNode, score float64, member string) bool {
+	return n.score < score || (n.score == score && n.member < member)
+}
+
+func zsetRandomLevel() int {
+	level := 1
+	for level < zsetMaxLevel && rand.Float64() < zsetP {
+		level++
+	}
+	return level
+}
+
+// insert adds a member that isn't already in the skiplist.
+func (z *zset) insert(score float64, member string) {
+	var update [zsetMaxLevel]*zsetNode
+	var rank [zsetMaxLevel]int
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		if i != z.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.level[i].forward != nil && zsetLess(x.level[i].forward, score, member) {
+			rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+	level := zsetRandomLevel()
+	if level > z.level {
+		for i := z.level; i < level; i++ {
+			update[i] = z.head
+			update[i].level[i].span = z.length
+		}
+		z.level = level
+	}
+	x = &zsetNode{member: member, score: score, level: make([]zsetLevel, level)}
+	for i := 0; i < level; i++ {
+		x.level[i].forward = update[i].level[i].forward
+		update[i].level[i].forward = x
+		x.level[i].span = update[i].level[i].span - (rank[0] - rank[i])
+		update[i].level[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < z.level; i++ {
+		update[i].level[i].span++
+	}
+	if update[0] != z.head {
+		x.backward = update[0]
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x
+	} else {
+		z.tail = x
+	}
+	z.length++
+}
+
+// remove deletes a member from the skiplist.
+func (z *zset) remove(score float64, member string) {
+	var update [zsetMaxLevel]*zsetNode
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && zsetLess(x.level[i].forward, score, member) {
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+	x = x.level[0].forward
+	if x == nil || x.score != score || x.member != member {
+		return
+	}
+	for i := 0; i < z.level; i++ {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		z.tail = x.backward
+	}
+	for z.level > 1 && z.head.level[z.level-1].forward == nil {
+		z.level--
+	}
+	z.length--
+}
+
+// rank returns the 0 based rank of a member in the skiplist.
+func (z *zset) rank(score float64, member string) int {
+	rank := 0
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && zsetLess(x.level[i].forward, score, member) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+		if f := x.level[i].forward; f != nil && f.score == score && f.member == member {
+			return rank + x.level[i].span - 1
+		}
+	}
+	return rank
+}
+
+// byRank returns the node at a 0 based rank.
+func (z *zset) byRank(rank int) *zsetNode {
+	traversed := 0
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span <= rank+1 {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		if traversed == rank+1 {
+			return x
+		}
+	}
+	return nil
+}
+
+// firstAtLeast returns the first node with a score of at least min.
+func (z *zset) firstAtLeast(min float64) *zsetNode {
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && x.level[i].forward.score < min {
+			x = x.level[i].forward
+		}
+	}
+	return x.level[0].forward
+}
+
+// lastAtMost returns the last node with a score of at most max.
+func (z *zset) lastAtMost(max float64) *zsetNode {
+	x := z.head
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && x.level[i].forward.score <= max {
+			x = x.level[i].forward
+		}
+	}
+	if x == z.head {
+		return nil
+	}
+	return x
+}
+
+// set adds the member or updates its score, returning true if it's new.
+func (z *zset) set(member string, score float64) bool {
+	old, ok := z.dict[member]
+	if ok {
+		if old == score {
+			return false
+		}
+		z.remove(old, member)
+	} else {
+		z.bytes += len(member) + 8 + elementOverhead
+	}
+	z.dict[member] = score
+	z.insert(score, member)
+	return !ok
+}
+
+// del deletes the member, returning true if it existed.
+func (z *zset) del(member string) bool {
+	score, ok := z.dict[member]
+	if ok {
+		delete(z.dict, member)
+		z.remove(score, member)
+		z.bytes -= len(member) + 8 + elementOverhead
+	}
+	return ok
+}
+
+// getZset returns the sorted set stored at key and its entry index. With
+// create, a missing key gets a new, empty sorted set. A key holding something
+// other than a sorted set is ErrWrongType.
+func (c *Cache) getZset(key string, create bool) (*zset, int32, error) {
+	i := c.getElement(key)
+	if i == nilIndex {
+		if !create {
+			return nil, nilIndex, ErrNotFound
+		}
+		z := newZset()
+		return z, c.insertObject(key, z), nil
+	}
+	z, ok := c.objects[i].(*zset)
+	if !ok {
+		return nil, nilIndex, ErrWrongType
+	}
+	return z, i, nil
+}
+
+// ZAdd adds members to the sorted set stored at key, or updates the scores of
+// existing members, creating the sorted set if it doesn't exist. It returns
+// the number of members added. If ttl is not zero, the TTL of the whole
+// sorted set is set to it. A NaN score, which can't be ordered, is
+// ErrInvalid, and nothing is added.
+func (c *Cache) ZAdd(key string, members []ScoredMember, ttl time.Duration) (int, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	for _, m := range members {
+		if math.IsNaN(m.Score) {
+			return 0, ErrInvalid
+		}
+	}
+	z, i, err := c.getZset(key, true)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, m := range members {
+		if z.set(m.Member, m.Score) {
+			added++
+		}
+	}
+	c.valueUpdated(i, ttl)
+	return added, nil
+}
+
+// ZIncrBy adds delta to the score of member in the sorted set stored at key
+// and returns the new score. Missing sorted sets and members start at 0. As
+// with ZAdd, a NaN delta, or a NaN result from adding an infinite delta to
+// the opposite infinity, is ErrInvalid, and the score is left as it was.
+func (c *Cache) ZIncrBy(key string, member string, delta float64) (float64, error) {
+	if math.IsNaN(delta) {
+		return 0, ErrInvalid
+	}
+	z, i, err := c.getZset(key, true)
+	if err != nil {
+		return 0, err
+	}
+	score := z.dict[member] + delta
+	if math.IsNaN(score) {
+		return 0, ErrInvalid
+	}
+	z.set(member, score)
+	c.valueUpdated(i, 0)
+	return score, nil
+}
+
+// ZRem removes members from the sorted set stored at key and returns the
+// number that were members. A sorted set left empty is deleted.
+func (c *Cache) ZRem(key string, members ...string) (int, error) {
+	z, i, err := c.getZset(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, member := range members {
+		if z.del(member) {
+			removed++
+		}
+	}
+	if z.length == 0 {
+		c.removeEntry(i)
+		return removed, nil
+	}
+	if removed != 0 {
+		c.valueUpdated(i, 0)
+	}
+	return removed, nil
+}
+
+// ZRange returns the members of the sorted set stored at key from rank start
+// to stop, inclusive, lowest score first (or highest first with reverse).
+// Negative ranks count from the end, as in LRange, and a missing key is an
+// empty sorted set.
+func (c *Cache) ZRange(key string, start, stop int, reverse bool) ([]ScoredMember, error) {
+	z, _, err := c.getZset(key, false)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	start, stop = listRange(z.length, start, stop)
+	if start == stop {
+		return nil, nil
+	}
+	members := make([]ScoredMember, 0, stop-start)
+	if reverse {
+		for x := z.byRank(z.length - 1 - start); len(members) < stop-start; x = x.backward {
+			members = append(members, ScoredMember{x.member, x.score})
+		}
+	} else {
+		for x := z.byRank(start); len(members) < stop-start; x = x.level[0].forward {
+			members = append(members, ScoredMember{x.member, x.score})
+		}
+	}
+	return members, nil
+}
+
+// ZRangeByScore returns the members of the sorted set stored at key with
+// scores between min and max, inclusive, lowest score first (or highest first
+// with reverse). A missing key is an empty sorted set.
+func (c *Cache) ZRangeByScore(key string, min, max float64, reverse bool) ([]ScoredMember, error) {
+	z, _, err := c.getZset(key, false)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var members []ScoredMember
+	if reverse {
+		for x := z.lastAtMost(max); x != nil && x.score >= min; x = x.backward {
+			members = append(members, ScoredMember{x.member, x.score})
+		}
+	} else {
+		for x := z.firstAtLeast(min); x != nil && x.score <= max; x = x.level[0].forward {
+			members = append(members, ScoredMember{x.member, x.score})
+		}
+	}
+	return members, nil
+}
+
+// ZRank returns the 0 based rank of member in the sorted set stored at key,
+// lowest score first (or highest first with reverse). A missing key or member
+// is ErrNotFound.
+func (c *Cache) ZRank(key string, member string, reverse bool) (int, error) {
+	z, _, err := c.getZset(key, false)
+	if err != nil {
+		return 0, err
+	}
+	score, ok := z.dict[member]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	rank := z.rank(score, member)
+	if reverse {
+		rank = z.length - 1 - rank
+	}
+	return rank, nil
+}
+
+// ZCard returns the number of members in the sorted set stored at key, or 0 if
+// it doesn't exist.
+func (c *Cache) ZCard(key string) (int, error) {
+	z, _, err := c.getZset(key, false)
+	if err == ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return z.length, nil
+}
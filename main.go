@@ -7,17 +7,26 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/joshrotenberg/grpc-cache/disk"
 	"github.com/joshrotenberg/grpc-cache/server"
 )
 
 var (
 	serverAddr      string
 	cacheMaxEntries int
+	cacheMaxBytes   int64
+	spillDir        string
+	spillMaxBytes   int64
+	streamWindow    int
 )
 
 func init() {
 	flag.StringVar(&serverAddr, "addr", "", "host:port to listen on")
 	flag.IntVar(&cacheMaxEntries, "maxEntries", 0, "maxiumum cache entries")
+	flag.Int64Var(&cacheMaxBytes, "maxBytes", 0, "maximum total size of cached items in bytes")
+	flag.StringVar(&spillDir, "spillDir", "", "directory to spill evicted items to (disabled if empty)")
+	flag.Int64Var(&spillMaxBytes, "spillMaxBytes", 1<<30, "maximum size of the spill log in bytes")
+	flag.IntVar(&streamWindow, "streamWindow", 64, "requests each stream runs concurrently")
 	flag.Parse()
 }
 func main() {
@@ -29,6 +38,15 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	s.WithMaxBytes(cacheMaxBytes).WithStreamWindow(streamWindow)
+	if spillDir != "" {
+		store, err := disk.Open(spillDir, spillMaxBytes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer store.Close()
+		s.WithTier(store)
+	}
 	s.Start()
 	<-sigs
 	s.Stop()
@@ -0,0 +1,64 @@
+package server
+
+import (
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// blockingPop pops from the head or tail of the list at in.Item.Key, waiting
+// for a push if the list is empty.
+func (s *CacheServer) blockingPop(ctx context.Context, in *pb.CacheRequest, op pb.CacheRequest_Operation) (*pb.CacheResponse, error) {
+	key := in.Item.Key
+	for {
+		s.cache.Lock()
+		before := s.watchedCAS(key)
+		var value []byte
+		var err error
+		if op == pb.CacheRequest_LPOP {
+			value, err = s.cache.LPop(key)
+		} else {
+			value, err = s.cache.RPop(key)
+		}
+		if err != lru.ErrNotFound {
+			if err == nil {
+				s.notify(op, in.Item, before)
+			}
+			s.cache.Unlock()
+			return cacheResponse(err, op, &pb.CacheItem{Key: key, Value: value})
+		}
+		ch := s.poppers.wait(key)
+		s.cache.Unlock()
+
+		select {
+		case <-ch:
+		case <-s.poppers.closed:
+			return nil, status.Errorf(codes.Unavailable, "%s error: server stopping", op)
+		case <-ctx.Done():
+			s.cache.Lock()
+			s.poppers.cancel(key, ch)
+			s.cache.Unlock()
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, status.Errorf(codes.DeadlineExceeded, "%s error: timed out waiting for '%s'", op, key)
+			}
+			return nil, status.Errorf(codes.Canceled, "%s error: canceled waiting for '%s'", op, key)
+		}
+	}
+}
+
+// BLPop removes and returns the first element of a list, waiting for one to
+// be pushed if the list is empty or doesn't exist. It waits until the call's
+// deadline, if any.
+func (s *CacheServer) BLPop(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	return s.blockingPop(ctx, in, pb.CacheRequest_LPOP)
+}
+
+// BRPop removes and returns the last element of a list, waiting for one to be
+// pushed if the list is empty or doesn't exist. It waits until the call's
+// deadline, if any.
+func (s *CacheServer) BRPop(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	return s.blockingPop(ctx, in, pb.CacheRequest_RPOP)
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"time"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// Lock acquires a lock, returning its owner and fencing tokens. If the lock is
+// held, it fails with AlreadyExists or, with wait, waits until the lock is
+// released or expires (or the call's deadline passes).
+func (s *CacheServer) Lock(ctx context.Context, in *pb.LockRequest) (*pb.LockResponse, error) {
+	ttl := time.Duration(in.TtlMs) * time.Millisecond
+	for {
+		s.cache.Lock()
+		owner, fence, err := s.cache.AcquireLock(in.Key, ttl)
+		if err != lru.ErrExists || !in.Wait {
+			if err == nil {
+				s.notifyKey(pb.WatchEvent_SET, in.Key)
+			}
+			s.cache.Unlock()
+			if err != nil {
+				return nil, rpcError(err, "Lock", in.Key)
+			}
+			return &pb.LockResponse{Key: in.Key, Owner: owner, Fence: fence}, nil
+		}
+		var expiresIn time.Duration
+		if info, err := s.cache.Inspect(in.Key); err == nil {
+			expiresIn = info.TTL
+		}
+		ch := s.lockers.wait(in.Key)
+		s.cache.Unlock()
+		if err := s.waitLock(ctx, in.Key, ch, expiresIn); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitLock waits on ch for the lock at key to be released. Expiry is lazy, so
+// rather than being woken when the lock expires, it returns once it's due to
+// so the caller can try again.
+func (s *CacheServer) waitLock(ctx context.Context, key string, ch chan struct{}, expiresIn time.Duration) error {
+	var expired <-chan time.Time
+	if expiresIn > 0 {
+		timer := time.NewTimer(expiresIn)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-expired:
+		s.cache.Lock()
+		s.lockers.cancel(key, ch)
+		s.cache.Unlock()
+		return nil
+	case <-s.lockers.closed:
+		return status.Errorf(codes.Unavailable, "Lock error: server stopping")
+	case <-ctx.Done():
+		s.cache.Lock()
+		s.lockers.cancel(key, ch)
+		s.cache.Unlock()
+		if ctx.Err() == context.DeadlineExceeded {
+			return status.Errorf(codes.DeadlineExceeded, "Lock error: timed out waiting for '%s'", key)
+		}
+		return status.Errorf(codes.Canceled, "Lock error: canceled waiting for '%s'", key)
+	}
+}
+
+// Unlock releases a lock held by the given owner, waking anyone waiting for
+// it.
+func (s *CacheServer) Unlock(ctx context.Context, in *pb.LockRequest) (*pb.LockResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	if err := s.cache.ReleaseLock(in.Key, in.Owner); err != nil {
+		return nil, rpcError(err, "Unlock", in.Key)
+	}
+	s.lockers.wake(in.Key)
+	s.notifyKey(pb.WatchEvent_DELETE, in.Key)
+	return &pb.LockResponse{Key: in.Key, Owner: in.Owner}, nil
+}
+
+// Renew extends the lease on a lock held by the given owner.
+func (s *CacheServer) Renew(ctx context.Context, in *pb.LockRequest) (*pb.LockResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	fence, err := s.cache.RenewLock(in.Key, in.Owner, time.Duration(in.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, rpcError(err, "Renew", in.Key)
+	}
+	s.notifyKey(pb.WatchEvent_TOUCH, in.Key)
+	return &pb.LockResponse{Key: in.Key, Owner: in.Owner, Fence: fence}, nil
+}
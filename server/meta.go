@@ -0,0 +1,149 @@
+package server
+
+import (
+	"time"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// metaResponse turns a meta command's error into either an error or, in quiet
+// mode, a response with miss set.
+func metaResponse(err error, cmd string, in *pb.MetaRequest) (*pb.MetaResponse, error) {
+	if err == lru.ErrNotFound && in.Quiet {
+		return &pb.MetaResponse{Key: in.Key, Miss: true}, nil
+	}
+	return nil, rpcError(err, cmd, in.Key)
+}
+
+// MetaGet gets an item along with the metadata selected by the return_* flags,
+// optionally touching it, vivifying it on a miss or handing out a recache win.
+func (s *CacheServer) MetaGet(ctx context.Context, in *pb.MetaRequest) (*pb.MetaResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	result, err := s.cache.MetaGet(in.Key, lru.MetaGetOptions{
+		NoBump:     in.NoBump,
+		Touch:      in.Touch,
+		TTL:        time.Duration(in.Ttl) * time.Second,
+		Vivify:     in.Vivify,
+		VivifyTTL:  time.Duration(in.VivifyTtl) * time.Second,
+		RecacheTTL: time.Duration(in.RecacheTtl) * time.Second,
+	})
+	if err != nil {
+		return metaResponse(err, "MetaGet", in)
+	}
+	if result.Vivified {
+		// the placeholder is a new item, so watchers see it like a set
+		s.notifyKey(pb.WatchEvent_SET, in.Key)
+	} else if in.Touch {
+		s.notifyKey(pb.WatchEvent_TOUCH, in.Key)
+	}
+	resp := &pb.MetaResponse{
+		Key:     in.Key,
+		Win:     result.Win,
+		Stale:   result.Stale,
+		WinSent: result.WinSent,
+	}
+	if in.ReturnValue {
+		resp.Value = result.Value
+	}
+	if in.ReturnCas {
+		resp.Cas = result.CAS
+	}
+	if in.ReturnTtl {
+		resp.Ttl = -1
+		if result.TTL != 0 {
+			resp.Ttl = int64(result.TTL / time.Second)
+		}
+	}
+	if in.ReturnSize {
+		resp.Size = uint64(result.Size)
+	}
+	if in.ReturnHit {
+		resp.HitBefore = result.HitBefore
+	}
+	if in.ReturnLastAccess {
+		resp.LastAccess = uint64(result.LastAccess / time.Second)
+	}
+	return resp, nil
+}
+
+// MetaSet stores an item using the mode in set_mode, optionally compared
+// against cas.
+func (s *CacheServer) MetaSet(ctx context.Context, in *pb.MetaRequest) (*pb.MetaResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	cas, err := s.cache.MetaSet(in.Key, in.Value, lru.MetaSetOptions{
+		Mode:       lru.MetaSetMode(in.SetMode),
+		TTL:        time.Duration(in.Ttl) * time.Second,
+		CAS:        in.Cas,
+		Invalidate: in.Invalidate,
+		Vivify:     in.Vivify,
+	})
+	if err == lru.ErrExists && in.Cas == 0 {
+		// with no CAS to compare, it's ADD finding the item already there
+		return nil, rpcError(err, "MetaSet ADD", in.Key)
+	}
+	if err != nil {
+		return metaResponse(err, "MetaSet", in)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	resp := &pb.MetaResponse{Key: in.Key}
+	if in.ReturnCas {
+		resp.Cas = cas
+	}
+	return resp, nil
+}
+
+// MetaDelete deletes an item or, with invalidate, marks it stale.
+func (s *CacheServer) MetaDelete(ctx context.Context, in *pb.MetaRequest) (*pb.MetaResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	err := s.cache.MetaDelete(in.Key, lru.MetaDeleteOptions{
+		CAS:        in.Cas,
+		Invalidate: in.Invalidate,
+		TTL:        time.Duration(in.Ttl) * time.Second,
+	})
+	if err != nil {
+		return metaResponse(err, "MetaDelete", in)
+	}
+	if in.Invalidate {
+		// the item is still there, just with a new cas
+		s.notifyKey(pb.WatchEvent_TOUCH, in.Key)
+	} else {
+		s.notifyKey(pb.WatchEvent_DELETE, in.Key)
+	}
+	return &pb.MetaResponse{Key: in.Key}, nil
+}
+
+// MetaArithmetic increments or decrements a counter by delta, optionally
+// vivifying it on a miss. The new value is returned in number.
+func (s *CacheServer) MetaArithmetic(ctx context.Context, in *pb.MetaRequest) (*pb.MetaResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	n, cas, err := s.cache.MetaArithmetic(in.Key, lru.MetaArithmeticOptions{
+		Decrement: in.ArithmeticMode == pb.MetaRequest_DECREMENT,
+		Delta:     in.Delta,
+		CAS:       in.Cas,
+		Touch:     in.Touch,
+		TTL:       time.Duration(in.Ttl) * time.Second,
+		Vivify:    in.Vivify,
+		Initial:   in.Initial,
+		VivifyTTL: time.Duration(in.VivifyTtl) * time.Second,
+	})
+	if err != nil {
+		return metaResponse(err, "MetaArithmetic", in)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	resp := &pb.MetaResponse{Key: in.Key, Number: n}
+	if in.ReturnCas {
+		resp.Cas = cas
+	}
+	return resp, nil
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"path"
+	"sync"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultSubscribeBuffer is the number of messages buffered per subscriber
+// when the request doesn't ask for a specific size.
+const defaultSubscribeBuffer = 256
+
+// subscriber is a single Subscribe stream.
+type subscriber struct {
+	channels []string
+	patterns []string
+	policy   pb.SubscribeRequest_SlowPolicy
+	messages chan *pb.Message
+	// dropped counts messages dropped since the last one that was queued
+	dropped uint64
+	// slow is closed when a DISCONNECT subscriber falls behind
+	slow chan struct{}
+}
+
+// pubsub fans published messages out to subscribers. Like the watchers,
+// publishing never blocks on a slow subscriber; what happens instead depends
+// on the subscriber's SlowPolicy.
+type pubsub struct {
+	sync.Mutex
+	// channels maps channel names to their subscribers
+	channels map[string]map[*subscriber]struct{}
+	// patterned is the set of subscribers with pattern subscriptions
+	patterned map[*subscriber]struct{}
+	count     int
+	closed    bool
+}
+
+func newPubsub() *pubsub {
+	return &pubsub{
+		channels:  make(map[string]map[*subscriber]struct{}),
+		patterned: make(map[*subscriber]struct{}),
+	}
+}
+
+// add registers a new subscriber for the request. If pubsub has been closed,
+// the returned subscriber's channel is already closed. An empty request or a
+// malformed pattern is an InvalidArgument error.
+func (ps *pubsub) add(in *pb.SubscribeRequest) (*subscriber, error) {
+	if len(in.Channels) == 0 && len(in.Patterns) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Subscribe error: no channels or patterns")
+	}
+	for _, pattern := range in.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Subscribe error: bad pattern '%s'", pattern)
+		}
+	}
+	buffer := int(in.Buffer)
+	if buffer == 0 {
+		buffer = defaultSubscribeBuffer
+	}
+	sub := &subscriber{
+		channels: in.Channels,
+		patterns: in.Patterns,
+		policy:   in.SlowPolicy,
+		messages: make(chan *pb.Message, buffer),
+		slow:     make(chan struct{}),
+	}
+
+	ps.Lock()
+	defer ps.Unlock()
+	if ps.closed {
+		close(sub.messages)
+		return sub, nil
+	}
+	for _, channel := range sub.channels {
+		subs, ok := ps.channels[channel]
+		if !ok {
+			subs = make(map[*subscriber]struct{})
+			ps.channels[channel] = subs
+		}
+		subs[sub] = struct{}{}
+	}
+	if len(sub.patterns) != 0 {
+		ps.patterned[sub] = struct{}{}
+	}
+	ps.count++
+	return sub, nil
+}
+
+// remove unregisters the subscriber. It's safe to call more than once.
+func (ps *pubsub) remove(sub *subscriber) {
+	ps.Lock()
+	defer ps.Unlock()
+	ps.unlink(sub)
+}
+
+// unlink does the work of remove with the lock held.
+func (ps *pubsub) unlink(sub *subscriber) {
+	found := false
+	for _, channel := range sub.channels {
+		if subs, ok := ps.channels[channel]; ok {
+			if _, ok := subs[sub]; ok {
+				found = true
+				delete(subs, sub)
+			}
+			if len(subs) == 0 {
+				delete(ps.channels, channel)
+			}
+		}
+	}
+	if _, ok := ps.patterned[sub]; ok {
+		found = true
+		delete(ps.patterned, sub)
+	}
+	if found {
+		ps.count--
+	}
+}
+
+// close ends all current and future subscriptions.
+func (ps *pubsub) close() {
+	ps.Lock()
+	defer ps.Unlock()
+	closed := make(map[*subscriber]struct{})
+	for _, subs := range ps.channels {
+		for sub := range subs {
+			closed[sub] = struct{}{}
+		}
+	}
+	for sub := range ps.patterned {
+		closed[sub] = struct{}{}
+	}
+	for sub := range closed {
+		ps.unlink(sub)
+		close(sub.messages)
+	}
+	ps.closed = true
+}
+
+// publish delivers the message to every subscriber of the channel and every
+// subscriber with a matching pattern, at most once per subscriber, and
+// returns the number of subscribers it was queued for.
+func (ps *pubsub) publish(channel string, message []byte) int {
+	ps.Lock()
+	defer ps.Unlock()
+
+	receivers := 0
+	seen := make(map[*subscriber]bool)
+	for sub := range ps.channels[channel] {
+		seen[sub] = true
+		if ps.deliver(sub, &pb.Message{Channel: channel, Message: message}) {
+			receivers++
+		}
+	}
+	for sub := range ps.patterned {
+		if seen[sub] {
+			continue
+		}
+		for _, pattern := range sub.patterns {
+			if ok, _ := path.Match(pattern, channel); ok {
+				if ps.deliver(sub, &pb.Message{Channel: channel, Pattern: pattern, Message: message}) {
+					receivers++
+				}
+				break
+			}
+		}
+	}
+	return receivers
+}
+
+// deliver queues the message for the subscriber, applying its slow policy if
+// the buffer is full. It returns true if the message was queued.
+func (ps *pubsub) deliver(sub *subscriber, msg *pb.Message) bool {
+	msg.Dropped = sub.dropped
+	select {
+	case sub.messages <- msg:
+		sub.dropped = 0
+		return true
+	default:
+	}
+	if sub.policy == pb.SubscribeRequest_DISCONNECT {
+		ps.unlink(sub)
+		close(sub.slow)
+		return false
+	}
+	sub.dropped++
+	return false
+}
+
+// stats returns the number of subscribers, channels with subscribers and
+// pattern subscriptions.
+func (ps *pubsub) stats() (subscribers, channels, patterns int) {
+	ps.Lock()
+	defer ps.Unlock()
+	for sub := range ps.patterned {
+		patterns += len(sub.patterns)
+	}
+	return ps.count, len(ps.channels), patterns
+}
+
+// Publish sends a message to the channel's subscribers and returns how many
+// received it. Messages aren't stored; with no subscribers they're discarded.
+func (s *CacheServer) Publish(ctx context.Context, in *pb.PublishRequest) (*pb.PublishResponse, error) {
+	receivers := s.pubsub.publish(in.Channel, in.Message)
+	return &pb.PublishResponse{Receivers: uint64(receivers)}, nil
+}
+
+// Subscribe streams messages published to the requested channels and
+// patterns until the client goes away or the server is stopped. A subscriber
+// that can't keep up either has messages dropped (the next delivered
+// message's dropped field says how many) or is disconnected, depending on its
+// slow_policy.
+func (s *CacheServer) Subscribe(in *pb.SubscribeRequest, stream pb.Cache_SubscribeServer) error {
+	sub, err := s.pubsub.add(in)
+	if err != nil {
+		return err
+	}
+	defer s.pubsub.remove(sub)
+	for {
+		select {
+		case msg, ok := <-sub.messages:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-sub.slow:
+			return status.Errorf(codes.ResourceExhausted, "Subscribe error: subscriber too slow")
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"time"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// Enqueue adds a message to a queue, creating it if needed, and returns the
+// message's ID.
+func (s *CacheServer) Enqueue(ctx context.Context, in *pb.QueueRequest) (*pb.QueueResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	id, err := s.cache.Enqueue(in.Key, in.Body, time.Duration(in.DelayMs)*time.Millisecond, time.Duration(in.Ttl)*time.Second)
+	if err != nil {
+		return nil, rpcError(err, "Enqueue", in.Key)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	return &pb.QueueResponse{Key: in.Key, Id: id}, nil
+}
+
+// Receive hands out the next visible message in a queue, hiding it for the
+// visibility timeout. It fails with NotFound if there isn't one.
+func (s *CacheServer) Receive(ctx context.Context, in *pb.QueueRequest) (*pb.QueueResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	m, err := s.cache.Receive(in.Key, lru.ReceiveOptions{
+		Visibility:  time.Duration(in.VisibilityMs) * time.Millisecond,
+		MaxReceives: int(in.MaxReceives),
+		DeadLetter:  in.DeadLetter,
+	})
+	if err != nil {
+		return nil, rpcError(err, "Receive", in.Key)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	return &pb.QueueResponse{
+		Key:      in.Key,
+		Id:       m.ID,
+		Body:     m.Body,
+		Receipt:  m.Receipt,
+		Receives: uint32(m.Receives),
+	}, nil
+}
+
+// Ack deletes a received message once it's been handled.
+func (s *CacheServer) Ack(ctx context.Context, in *pb.QueueRequest) (*pb.QueueResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	if err := s.cache.Ack(in.Key, in.Receipt); err != nil {
+		return nil, rpcError(err, "Ack", in.Key)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	return &pb.QueueResponse{Key: in.Key, Receipt: in.Receipt}, nil
+}
+
+// Nack returns a received message to the queue, to be delivered again after
+// the delay.
+func (s *CacheServer) Nack(ctx context.Context, in *pb.QueueRequest) (*pb.QueueResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	if err := s.cache.Nack(in.Key, in.Receipt, time.Duration(in.DelayMs)*time.Millisecond); err != nil {
+		return nil, rpcError(err, "Nack", in.Key)
+	}
+	s.notifyKey(pb.WatchEvent_SET, in.Key)
+	return &pb.QueueResponse{Key: in.Key, Receipt: in.Receipt}, nil
+}
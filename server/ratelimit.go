@@ -0,0 +1,50 @@
+package server
+
+import (
+	"time"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+	"golang.org/x/net/context"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// RateLimit checks a request against the rate limit stored at the key, using
+// up its cost if it's allowed, or with check_only just reports the limit. The
+// check and update happen under the cache lock, so concurrent callers sharing
+// a key can't overrun the limit.
+func (s *CacheServer) RateLimit(ctx context.Context, in *pb.RateLimitRequest) (*pb.RateLimitResponse, error) {
+	opts := lru.RateLimitOptions{
+		Rate:   in.Rate,
+		Period: time.Duration(in.PeriodMs) * time.Millisecond,
+		Burst:  in.Burst,
+		Cost:   in.Cost,
+	}
+	if opts.Period == 0 {
+		opts.Period = time.Second
+	}
+	if in.CheckOnly {
+		// a cost of 0 checks the limit without using any of it
+		opts.Cost = 0
+	} else if opts.Cost == 0 {
+		opts.Cost = 1
+	}
+
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	result, err := s.cache.RateLimit(in.Key, opts)
+	if err != nil {
+		return nil, rpcError(err, "RateLimit", in.Key)
+	}
+	if result.Allowed && opts.Cost != 0 {
+		s.notifyKey(pb.WatchEvent_SET, in.Key)
+	}
+	// round the retry up, so retrying after it won't be too early
+	return &pb.RateLimitResponse{
+		Allowed:      result.Allowed,
+		Remaining:    result.Remaining,
+		RetryAfterMs: uint64((result.RetryAfter + time.Millisecond - 1) / time.Millisecond),
+		ResetAfterMs: uint64(result.ResetAfter / time.Millisecond),
+	}, nil
+}
@@ -1,9 +1,9 @@
 package server
 
 import (
-	"io"
 	"log"
 	"net"
+	"sort"
 	"time"
 
 	pb "github.com/joshrotenberg/grpc-cache/cache"
@@ -20,6 +20,12 @@ type CacheServer struct {
 	cache      *lru.Cache
 	grpcServer *grpc.Server
 	listener   net.Listener
+	watchers   *watchers
+	pubsub     *pubsub
+	poppers    *waiters
+	lockers    *waiters
+	// the number of requests each Stream runs at once
+	streamWindow int
 }
 
 // NewWithListener returns a new instance of the server given an initialized listener and
@@ -30,7 +36,14 @@ func NewWithListener(listener net.Listener, maxEntries int) *CacheServer {
 		cache:      lru.New(maxEntries),
 		grpcServer: grpcServer,
 		listener:   listener,
+		watchers:   newWatchers(),
+		pubsub:     newPubsub(),
+		poppers:    newWaiters(),
+		lockers:    newWaiters(),
+
+		streamWindow: defaultStreamWindow,
 	}
+	server.cache.WithEvictionHandler(lru.EvictionHandlerFunc(server.handleEviction))
 
 	pb.RegisterCacheServer(grpcServer, &server)
 	return &server
@@ -46,6 +59,30 @@ func New(address string, maxEntries int) (*CacheServer, error) {
 	return NewWithListener(listener, maxEntries), nil
 }
 
+// WithMaxBytes limits the total size of the items in the server's cache,
+// evicting via LRU to stay within it. See lru.Cache.WithMaxBytes.
+func (s *CacheServer) WithMaxBytes(n int64) *CacheServer {
+	s.cache.WithMaxBytes(n)
+	return s
+}
+
+// WithTier attaches a second level store to the server's cache that receives
+// items evicted via LRU and serves misses. See lru.Tier.
+func (s *CacheServer) WithTier(t lru.Tier) *CacheServer {
+	s.cache.WithTier(t)
+	return s
+}
+
+// WithStreamWindow sets how many requests each Stream reads ahead and runs
+// concurrently. 1 runs them one at a time, in order.
+func (s *CacheServer) WithStreamWindow(n int) *CacheServer {
+	if n < 1 {
+		n = 1
+	}
+	s.streamWindow = n
+	return s
+}
+
 // Start starts the cache server.
 func (s *CacheServer) Start() {
 	go func() {
@@ -57,22 +94,58 @@ func (s *CacheServer) Start() {
 
 // Stop tries to gracefull stop the server.
 func (s *CacheServer) Stop() {
+	// watch and subscribe streams never end on their own, and blocking pops
+	// and locks may not have a deadline
+	s.watchers.close()
+	s.pubsub.close()
+	s.cache.Lock()
+	s.poppers.close()
+	s.lockers.close()
+	s.cache.Unlock()
 	s.grpcServer.GracefulStop()
 }
 
-func cacheError(err error, op pb.CacheRequest_Operation, key string) error {
+// casCommands are the commands whose ErrExists means the item's CAS didn't
+// match, rather than that an item exists that shouldn't.
+var casCommands = map[string]bool{
+	pb.CacheRequest_DELETE.String():  true,
+	pb.CacheRequest_TOUCH.String():   true,
+	pb.CacheRequest_APPEND.String():  true,
+	pb.CacheRequest_PREPEND.String(): true,
+	"MetaSet":                        true,
+	"MetaDelete":                     true,
+	"MetaArithmetic":                 true,
+}
+
+// rpcError converts an error from the cache into a gRPC status error, naming
+// the command (an operation or an RPC) and the key it failed on. Errors it
+// doesn't know are returned as they are.
+func rpcError(err error, cmd string, key string) error {
 	switch err {
 	case lru.ErrNotFound:
-		return status.Errorf(codes.NotFound, "%s error: '%s' not found", op, key)
+		return status.Errorf(codes.NotFound, "%s error: '%s' not found", cmd, key)
 	case lru.ErrExists:
-		return status.Errorf(codes.AlreadyExists, "%s error: '%s' exists", op, key)
+		if casCommands[cmd] {
+			return status.Errorf(codes.FailedPrecondition, "%s error: '%s' has a different CAS", cmd, key)
+		}
+		return status.Errorf(codes.AlreadyExists, "%s error: '%s' exists", cmd, key)
+	case lru.ErrWrongType:
+		return status.Errorf(codes.FailedPrecondition, "%s error: '%s' holds the wrong kind of value", cmd, key)
+	case lru.ErrNotInteger:
+		return status.Errorf(codes.FailedPrecondition, "%s error: '%s' is not an integer", cmd, key)
+	case lru.ErrOverflow:
+		return status.Errorf(codes.OutOfRange, "%s error: '%s' would overflow", cmd, key)
+	case lru.ErrInvalid:
+		return status.Errorf(codes.InvalidArgument, "%s error: invalid argument for '%s'", cmd, key)
+	case lru.ErrNotOwner:
+		return status.Errorf(codes.FailedPrecondition, "%s error: '%s' is locked by another owner", cmd, key)
 	}
 	return err
 }
 
 func cacheResponse(err error, op pb.CacheRequest_Operation, item *pb.CacheItem) (*pb.CacheResponse, error) {
 	if err != nil {
-		return nil, cacheError(err, op, item.Key)
+		return nil, rpcError(err, op.String(), item.Key)
 	}
 	response := &pb.CacheResponse{
 		Item: item,
@@ -80,76 +153,414 @@ func cacheResponse(err error, op pb.CacheRequest_Operation, item *pb.CacheItem)
 	return response, err
 }
 
-// Stream ...
-func (s *CacheServer) Stream(stream pb.Cache_StreamServer) error {
-	for {
-		in, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-		log.Printf("i got %v", in)
-		resp, err := s.Call(stream.Context(), in)
-		if err != nil {
-			return err
-		}
-		if err := stream.Send(resp); err != nil {
-			return err
+// embeddedResponse returns the response for one operation of several, with
+// any error folded into its code and message rather than failing the call,
+// and the request's ID.
+func embeddedResponse(resp *pb.CacheResponse, err error, in *pb.CacheRequest) *pb.CacheResponse {
+	if err != nil {
+		resp = &pb.CacheResponse{Item: &pb.CacheItem{Key: in.GetItem().GetKey()}, Code: uint32(codes.Unknown), Message: err.Error()}
+		if st, ok := status.FromError(err); ok {
+			resp.Code = uint32(st.Code())
+			resp.Message = st.Message()
 		}
 	}
+	resp.RequestId = in.RequestId
+	return resp
+}
+
+func numberResponse(err error, op pb.CacheRequest_Operation, key string, n int64) (*pb.CacheResponse, error) {
+	if err != nil {
+		return nil, rpcError(err, op.String(), key)
+	}
+	return &pb.CacheResponse{Item: &pb.CacheItem{Key: key}, Number: n}, nil
+}
+
+// firstField returns the name of the first field in the request, for the hash
+// operations that take a single field.
+func firstField(in *pb.CacheRequest) string {
+	if len(in.Fields) == 0 {
+		return ""
+	}
+	return in.Fields[0].Field
+}
+
+// members converts set members from the request's values.
+func members(values [][]byte) []string {
+	m := make([]string, len(values))
+	for i, value := range values {
+		m[i] = string(value)
+	}
+	return m
+}
+
+// boolNumber converts a boolean result into a response number.
+func boolNumber(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// firstMember returns the first of the request's values, for the set
+// operations that take a single member.
+func firstMember(in *pb.CacheRequest) string {
+	if len(in.Values) == 0 {
+		return ""
+	}
+	return string(in.Values[0])
+}
+
+// scoredResponse converts sorted set members into a response.
+func scoredResponse(err error, op pb.CacheRequest_Operation, key string, result []lru.ScoredMember) (*pb.CacheResponse, error) {
+	if err != nil {
+		return nil, rpcError(err, op.String(), key)
+	}
+	response := &pb.CacheResponse{Item: &pb.CacheItem{Key: key}}
+	for _, m := range result {
+		response.Scored = append(response.Scored, &pb.ScoredMember{Member: []byte(m.Member), Score: m.Score})
+	}
+	return response, nil
 }
 
 // Call calls the cache operation in in.Operation
 func (s *CacheServer) Call(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
 
-	var err error
+	switch in.Operation {
+	case pb.CacheRequest_PEEK, pb.CacheRequest_INSPECT:
+		// read only, so these don't need to wait for each other
+		s.cache.RLock()
+		defer s.cache.RUnlock()
+		return s.call(in)
+	}
+
 	s.cache.Lock()
 	defer s.cache.Unlock()
+	before := s.watchedCAS(in.GetItem().GetKey())
+	resp, err := s.call(in)
+	if err == nil {
+		s.notify(in.Operation, in.Item, before)
+	}
+	return resp, err
+}
+
+// GetMulti gets each of the items, along with their CAS.
+func (s *CacheServer) GetMulti(ctx context.Context, in *pb.MultiRequest) (*pb.MultiResponse, error) {
+	return s.multi(in, pb.CacheRequest_GETS), nil
+}
+
+// SetMulti sets each of the items.
+func (s *CacheServer) SetMulti(ctx context.Context, in *pb.MultiRequest) (*pb.MultiResponse, error) {
+	return s.multi(in, pb.CacheRequest_SET), nil
+}
+
+// DeleteMulti deletes each of the items.
+func (s *CacheServer) DeleteMulti(ctx context.Context, in *pb.MultiRequest) (*pb.MultiResponse, error) {
+	return s.multi(in, pb.CacheRequest_DELETE), nil
+}
+
+// multi runs op on each of the items under a single lock.
+func (s *CacheServer) multi(in *pb.MultiRequest, op pb.CacheRequest_Operation) *pb.MultiResponse {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	response := &pb.MultiResponse{Responses: make([]*pb.CacheResponse, 0, len(in.Items))}
+	for _, item := range in.Items {
+		req := &pb.CacheRequest{Operation: op, Item: item}
+		before := s.watchedCAS(item.GetKey())
+		resp, err := s.call(req)
+		if err == nil {
+			s.notify(op, item, before)
+		}
+		response.Responses = append(response.Responses, embeddedResponse(resp, err, req))
+	}
+	return response
+}
+
+// Transaction runs the requested operations in order under a single cache
+// lock, after checking the watched items' CAS values, so no other call can see
+// or change the cache part way through. There's no rollback: operations that
+// fail don't stop the rest.
+func (s *CacheServer) Transaction(ctx context.Context, in *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+	s.cache.Lock()
+	defer s.cache.Unlock()
+
+	for _, item := range in.Watch {
+		var cas uint64
+		if info, err := s.cache.Inspect(item.Key); err == nil {
+			cas = info.CAS
+		}
+		if cas != item.Cas {
+			return &pb.TransactionResponse{Aborted: true, Conflict: item.Key}, nil
+		}
+	}
+	response := &pb.TransactionResponse{Responses: make([]*pb.CacheResponse, 0, len(in.Requests))}
+	for _, req := range in.Requests {
+		before := s.watchedCAS(req.GetItem().GetKey())
+		resp, err := s.call(req)
+		if err == nil {
+			s.notify(req.Operation, req.Item, before)
+		}
+		response.Responses = append(response.Responses, embeddedResponse(resp, err, req))
+	}
+	return response, nil
+}
 
+// call does the actual work for Call. The caller must hold the cache lock.
+func (s *CacheServer) call(in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	var err error
 	switch in.Operation {
 	case pb.CacheRequest_NOOP:
 		return cacheResponse(nil, in.Operation, in.Item)
 	case pb.CacheRequest_SET:
-		s.cache.Set(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
-		return cacheResponse(nil, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		cas, err := s.cache.Set(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_CAS:
-		err = s.cache.Cas(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second, uint64(in.Item.Cas))
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
-	case pb.CacheRequest_GET:
-		value, err := s.cache.Get(in.Item.Key)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value})
-	case pb.CacheRequest_GETS:
+		cas, err := s.cache.Cas(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second, uint64(in.Item.Cas))
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
+	case pb.CacheRequest_GET, pb.CacheRequest_GETS:
 		value, cas, err := s.cache.Gets(in.Item.Key)
+		if err == nil && in.IfCasDiffers != 0 && cas == in.IfCasDiffers {
+			return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key, Cas: cas}, NotModified: true}, nil
+		}
+		if in.Operation == pb.CacheRequest_GET {
+			cas = 0
+		}
+		// the value is the cache's own memory, and the response is sent after
+		// the lock is released
+		value = append([]byte(nil), value...)
 		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value, Cas: cas})
 	case pb.CacheRequest_ADD:
-		err = s.cache.Add(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		cas, err := s.cache.Add(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_REPLACE:
-		err = s.cache.Replace(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		cas, err := s.cache.Replace(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_DELETE:
-		s.cache.Delete(in.Item.Key)
-		return cacheResponse(nil, in.Operation, &pb.CacheItem{Key: in.Item.Key})
-	case pb.CacheRequest_TOUCH:
-		err = s.cache.Touch(in.Item.Key, time.Duration(in.Item.Ttl)*time.Second)
+		err = s.cache.Delete(in.Item.Key, in.Item.Cas)
 		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+	case pb.CacheRequest_TOUCH:
+		cas, err := s.cache.Touch(in.Item.Key, time.Duration(in.Item.Ttl)*time.Second, in.Item.Cas)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_APPEND:
-		err = s.cache.Append(in.Item.Key, in.Append, time.Duration(in.Item.Ttl)*time.Second)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		cas, err := s.cache.Append(in.Item.Key, in.Append, time.Duration(in.Item.Ttl)*time.Second, in.Item.Cas)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_PREPEND:
-		err = s.cache.Prepend(in.Item.Key, in.Prepend, time.Duration(in.Item.Ttl)*time.Second)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		cas, err := s.cache.Prepend(in.Item.Key, in.Prepend, time.Duration(in.Item.Ttl)*time.Second, in.Item.Cas)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Cas: cas})
 	case pb.CacheRequest_INCREMENT:
-		err = s.cache.Increment(in.Item.Key, in.Increment)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		n, cas, err := s.cache.Increment(in.Item.Key, in.Increment)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: lru.Uint64ToBytes(n), Cas: cas})
 	case pb.CacheRequest_DECREMENT:
-		err = s.cache.Decrement(in.Item.Key, in.Decrement)
-		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		n, cas, err := s.cache.Decrement(in.Item.Key, in.Decrement)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: lru.Uint64ToBytes(n), Cas: cas})
 	case pb.CacheRequest_FLUSHALL:
 		s.cache.FlushAll()
+		s.lockers.wakeAll()
 		return cacheResponse(nil, in.Operation, nil)
+	case pb.CacheRequest_INSPECT:
+		info, err := s.cache.Inspect(in.Item.Key)
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{
+			Item: &pb.CacheItem{Key: in.Item.Key, Cas: info.CAS},
+			Info: &pb.ItemInfo{
+				AgeMs:        uint64(info.Age / time.Millisecond),
+				TtlMs:        uint64(info.TTL / time.Millisecond),
+				Size:         uint64(info.Size),
+				Cas:          info.CAS,
+				LastAccessMs: uint64(info.LastAccess / time.Millisecond),
+				Tiered:       info.Tiered,
+			},
+		}, nil
+	case pb.CacheRequest_PEEK:
+		value, cas, err := s.cache.Peek(in.Item.Key)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value, Cas: cas})
+	case pb.CacheRequest_HSET:
+		fields := make(map[string][]byte, len(in.Fields))
+		for _, f := range in.Fields {
+			fields[f.Field] = f.Value
+		}
+		added, err := s.cache.HSet(in.Item.Key, fields, time.Duration(in.Item.Ttl)*time.Second)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(added))
+	case pb.CacheRequest_HGET:
+		value, err := s.cache.HGet(in.Item.Key, firstField(in))
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value})
+	case pb.CacheRequest_HDEL:
+		fields := make([]string, len(in.Fields))
+		for i, f := range in.Fields {
+			fields[i] = f.Field
+		}
+		deleted, err := s.cache.HDel(in.Item.Key, fields...)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(deleted))
+	case pb.CacheRequest_HGETALL:
+		fields, err := s.cache.HGetAll(in.Item.Key)
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		names := make([]string, 0, len(fields))
+		for field := range fields {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		response := &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key}}
+		for _, field := range names {
+			response.Fields = append(response.Fields, &pb.HashField{Field: field, Value: fields[field]})
+		}
+		return response, nil
+	case pb.CacheRequest_HINCRBY:
+		n, err := s.cache.HIncrBy(in.Item.Key, firstField(in), in.Delta)
+		return numberResponse(err, in.Operation, in.Item.Key, n)
+	case pb.CacheRequest_HLEN:
+		n, err := s.cache.HLen(in.Item.Key)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_LPUSH, pb.CacheRequest_RPUSH:
+		var n int
+		if in.Operation == pb.CacheRequest_LPUSH {
+			n, err = s.cache.LPush(in.Item.Key, in.Values, time.Duration(in.Item.Ttl)*time.Second)
+		} else {
+			n, err = s.cache.RPush(in.Item.Key, in.Values, time.Duration(in.Item.Ttl)*time.Second)
+		}
+		if err == nil {
+			s.poppers.wake(in.Item.Key)
+		}
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_LPOP:
+		value, err := s.cache.LPop(in.Item.Key)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value})
+	case pb.CacheRequest_RPOP:
+		value, err := s.cache.RPop(in.Item.Key)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value})
+	case pb.CacheRequest_LRANGE:
+		values, err := s.cache.LRange(in.Item.Key, int(in.Start), int(in.Stop))
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key}, Values: values}, nil
+	case pb.CacheRequest_LTRIM:
+		err = s.cache.LTrim(in.Item.Key, int(in.Start), int(in.Stop))
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+	case pb.CacheRequest_LLEN:
+		n, err := s.cache.LLen(in.Item.Key)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_SADD:
+		n, err := s.cache.SAdd(in.Item.Key, members(in.Values), time.Duration(in.Item.Ttl)*time.Second)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_SREM:
+		n, err := s.cache.SRem(in.Item.Key, members(in.Values)...)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_SISMEMBER:
+		ok, err := s.cache.SIsMember(in.Item.Key, firstMember(in))
+		return numberResponse(err, in.Operation, in.Item.Key, boolNumber(ok))
+	case pb.CacheRequest_SCARD:
+		n, err := s.cache.SCard(in.Item.Key)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_SMEMBERS, pb.CacheRequest_SINTER, pb.CacheRequest_SUNION, pb.CacheRequest_SDIFF:
+		var result []string
+		keys := append([]string{in.Item.Key}, in.Keys...)
+		switch in.Operation {
+		case pb.CacheRequest_SMEMBERS:
+			result, err = s.cache.SMembers(in.Item.Key)
+		case pb.CacheRequest_SINTER:
+			result, err = s.cache.SInter(keys...)
+		case pb.CacheRequest_SUNION:
+			result, err = s.cache.SUnion(keys...)
+		case pb.CacheRequest_SDIFF:
+			result, err = s.cache.SDiff(keys...)
+		}
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		response := &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key}}
+		for _, member := range result {
+			response.Values = append(response.Values, []byte(member))
+		}
+		return response, nil
+	case pb.CacheRequest_ZADD:
+		scored := make([]lru.ScoredMember, len(in.Scored))
+		for i, m := range in.Scored {
+			scored[i] = lru.ScoredMember{Member: string(m.Member), Score: m.Score}
+		}
+		n, err := s.cache.ZAdd(in.Item.Key, scored, time.Duration(in.Item.Ttl)*time.Second)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_ZINCRBY:
+		var member string
+		var delta float64
+		if len(in.Scored) != 0 {
+			member, delta = string(in.Scored[0].Member), in.Scored[0].Score
+		}
+		score, err := s.cache.ZIncrBy(in.Item.Key, member, delta)
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key}, Score: score}, nil
+	case pb.CacheRequest_ZREM:
+		n, err := s.cache.ZRem(in.Item.Key, members(in.Values)...)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_ZRANGE:
+		result, err := s.cache.ZRange(in.Item.Key, int(in.Start), int(in.Stop), in.Reverse)
+		return scoredResponse(err, in.Operation, in.Item.Key, result)
+	case pb.CacheRequest_ZRANGEBYSCORE:
+		result, err := s.cache.ZRangeByScore(in.Item.Key, in.Min, in.Max, in.Reverse)
+		return scoredResponse(err, in.Operation, in.Item.Key, result)
+	case pb.CacheRequest_ZRANK:
+		n, err := s.cache.ZRank(in.Item.Key, firstMember(in), in.Reverse)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_ZCARD:
+		n, err := s.cache.ZCard(in.Item.Key)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_PFADD:
+		changed, err := s.cache.PFAdd(in.Item.Key, in.Values, time.Duration(in.Item.Ttl)*time.Second)
+		return numberResponse(err, in.Operation, in.Item.Key, boolNumber(changed))
+	case pb.CacheRequest_PFCOUNT:
+		n, err := s.cache.PFCount(append([]string{in.Item.Key}, in.Keys...)...)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
+	case pb.CacheRequest_PFMERGE:
+		err = s.cache.PFMerge(in.Item.Key, in.Keys...)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+	case pb.CacheRequest_BFRESERVE:
+		err = s.cache.BFReserve(in.Item.Key, in.Capacity, in.ErrorRate, time.Duration(in.Item.Ttl)*time.Second)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+	case pb.CacheRequest_BFADD:
+		added, err := s.cache.BFAdd(in.Item.Key, []byte(firstMember(in)), time.Duration(in.Item.Ttl)*time.Second)
+		return numberResponse(err, in.Operation, in.Item.Key, boolNumber(added))
+	case pb.CacheRequest_BFEXISTS:
+		exists, err := s.cache.BFExists(in.Item.Key, []byte(firstMember(in)))
+		return numberResponse(err, in.Operation, in.Item.Key, boolNumber(exists))
+	case pb.CacheRequest_BFMEXISTS:
+		exists, err := s.cache.BFMExists(in.Item.Key, in.Values...)
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key}, Exists: exists}, nil
+	case pb.CacheRequest_GETSET, pb.CacheRequest_SETNX:
+		var old []byte
+		var existed bool
+		var cas uint64
+		if in.Operation == pb.CacheRequest_GETSET {
+			old, existed, cas, err = s.cache.GetSet(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
+		} else {
+			old, existed, cas, err = s.cache.SetNX(in.Item.Key, in.Item.Value, time.Duration(in.Item.Ttl)*time.Second)
+		}
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key, Value: old, Cas: cas}, Number: boolNumber(existed)}, nil
+	case pb.CacheRequest_GETDEL:
+		value, cas, err := s.cache.GetDel(in.Item.Key)
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value, Cas: cas})
+	case pb.CacheRequest_GETRANGE:
+		value, err := s.cache.GetRange(in.Item.Key, int(in.Start), int(in.Stop))
+		return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key, Value: value})
+	case pb.CacheRequest_SETRANGE:
+		n, cas, err := s.cache.SetRange(in.Item.Key, int(in.Start), in.Item.Value)
+		if err != nil {
+			return cacheResponse(err, in.Operation, &pb.CacheItem{Key: in.Item.Key})
+		}
+		return &pb.CacheResponse{Item: &pb.CacheItem{Key: in.Item.Key, Cas: cas}, Number: int64(n)}, nil
+	case pb.CacheRequest_STRLEN:
+		n, err := s.cache.StrLen(in.Item.Key)
+		return numberResponse(err, in.Operation, in.Item.Key, int64(n))
 	default:
 		return nil, status.Errorf(codes.Unimplemented, "unrecognized cache command %d", in.Operation)
 	}
@@ -234,3 +645,284 @@ func (s *CacheServer) FlushAll(ctx context.Context, in *pb.CacheRequest) (*pb.Ca
 	in.Operation = pb.CacheRequest_FLUSHALL
 	return s.Call(ctx, in)
 }
+
+// Inspect returns metadata about the item (age, remaining ttl, size, cas and
+// time since last access) without promoting it in the LRU or changing its cas.
+func (s *CacheServer) Inspect(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_INSPECT
+	return s.Call(ctx, in)
+}
+
+// Peek gets a key/value pair and its CAS value from the cache without promoting
+// it in the LRU.
+func (s *CacheServer) Peek(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_PEEK
+	return s.Call(ctx, in)
+}
+
+// HSet sets fields in a hash, creating it if needed.
+func (s *CacheServer) HSet(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HSET
+	return s.Call(ctx, in)
+}
+
+// HGet gets the value of a field in a hash.
+func (s *CacheServer) HGet(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HGET
+	return s.Call(ctx, in)
+}
+
+// HDel deletes fields from a hash.
+func (s *CacheServer) HDel(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HDEL
+	return s.Call(ctx, in)
+}
+
+// HGetAll gets all the fields and values in a hash.
+func (s *CacheServer) HGetAll(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HGETALL
+	return s.Call(ctx, in)
+}
+
+// HIncrBy increments the integer value of a field in a hash.
+func (s *CacheServer) HIncrBy(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HINCRBY
+	return s.Call(ctx, in)
+}
+
+// HLen returns the number of fields in a hash.
+func (s *CacheServer) HLen(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_HLEN
+	return s.Call(ctx, in)
+}
+
+// LPush pushes values onto the head of a list, creating it if needed.
+func (s *CacheServer) LPush(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_LPUSH
+	return s.Call(ctx, in)
+}
+
+// RPush pushes values onto the tail of a list, creating it if needed.
+func (s *CacheServer) RPush(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_RPUSH
+	return s.Call(ctx, in)
+}
+
+// LPop removes and returns the first element of a list.
+func (s *CacheServer) LPop(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_LPOP
+	return s.Call(ctx, in)
+}
+
+// RPop removes and returns the last element of a list.
+func (s *CacheServer) RPop(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_RPOP
+	return s.Call(ctx, in)
+}
+
+// LRange returns a range of elements from a list.
+func (s *CacheServer) LRange(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_LRANGE
+	return s.Call(ctx, in)
+}
+
+// LTrim trims a list to a range of elements.
+func (s *CacheServer) LTrim(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_LTRIM
+	return s.Call(ctx, in)
+}
+
+// LLen returns the length of a list.
+func (s *CacheServer) LLen(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_LLEN
+	return s.Call(ctx, in)
+}
+
+// SAdd adds members to a set, creating it if needed.
+func (s *CacheServer) SAdd(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SADD
+	return s.Call(ctx, in)
+}
+
+// SRem removes members from a set.
+func (s *CacheServer) SRem(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SREM
+	return s.Call(ctx, in)
+}
+
+// SIsMember checks whether a value is a member of a set.
+func (s *CacheServer) SIsMember(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SISMEMBER
+	return s.Call(ctx, in)
+}
+
+// SMembers returns the members of a set.
+func (s *CacheServer) SMembers(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SMEMBERS
+	return s.Call(ctx, in)
+}
+
+// SCard returns the number of members in a set.
+func (s *CacheServer) SCard(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SCARD
+	return s.Call(ctx, in)
+}
+
+// SInter returns the intersection of sets.
+func (s *CacheServer) SInter(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SINTER
+	return s.Call(ctx, in)
+}
+
+// SUnion returns the union of sets.
+func (s *CacheServer) SUnion(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SUNION
+	return s.Call(ctx, in)
+}
+
+// SDiff returns the members of the first set that aren't in the others.
+func (s *CacheServer) SDiff(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SDIFF
+	return s.Call(ctx, in)
+}
+
+// ZAdd adds members to a sorted set or updates their scores, creating it if
+// needed.
+func (s *CacheServer) ZAdd(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZADD
+	return s.Call(ctx, in)
+}
+
+// ZIncrBy adds to the score of a sorted set member.
+func (s *CacheServer) ZIncrBy(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZINCRBY
+	return s.Call(ctx, in)
+}
+
+// ZRem removes members from a sorted set.
+func (s *CacheServer) ZRem(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZREM
+	return s.Call(ctx, in)
+}
+
+// ZRange returns a range of sorted set members by rank.
+func (s *CacheServer) ZRange(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZRANGE
+	return s.Call(ctx, in)
+}
+
+// ZRangeByScore returns the sorted set members within a range of scores.
+func (s *CacheServer) ZRangeByScore(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZRANGEBYSCORE
+	return s.Call(ctx, in)
+}
+
+// ZRank returns the rank of a sorted set member.
+func (s *CacheServer) ZRank(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZRANK
+	return s.Call(ctx, in)
+}
+
+// ZCard returns the number of members in a sorted set.
+func (s *CacheServer) ZCard(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_ZCARD
+	return s.Call(ctx, in)
+}
+
+// PFAdd adds values to a HyperLogLog, creating it if needed.
+func (s *CacheServer) PFAdd(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_PFADD
+	return s.Call(ctx, in)
+}
+
+// PFCount returns the estimated number of distinct values added to one or
+// more HyperLogLogs.
+func (s *CacheServer) PFCount(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_PFCOUNT
+	return s.Call(ctx, in)
+}
+
+// PFMerge merges HyperLogLogs into another.
+func (s *CacheServer) PFMerge(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_PFMERGE
+	return s.Call(ctx, in)
+}
+
+// BFReserve creates a Bloom filter with a given capacity and error rate.
+func (s *CacheServer) BFReserve(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_BFRESERVE
+	return s.Call(ctx, in)
+}
+
+// BFAdd adds an item to a Bloom filter, creating it if needed.
+func (s *CacheServer) BFAdd(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_BFADD
+	return s.Call(ctx, in)
+}
+
+// BFExists checks whether an item may be in a Bloom filter.
+func (s *CacheServer) BFExists(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_BFEXISTS
+	return s.Call(ctx, in)
+}
+
+// BFMExists checks whether each of several items may be in a Bloom filter.
+func (s *CacheServer) BFMExists(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_BFMEXISTS
+	return s.Call(ctx, in)
+}
+
+// GetSet sets an item and returns its previous value.
+func (s *CacheServer) GetSet(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_GETSET
+	return s.Call(ctx, in)
+}
+
+// GetDel deletes an item and returns its value.
+func (s *CacheServer) GetDel(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_GETDEL
+	return s.Call(ctx, in)
+}
+
+// SetNX sets an item if it doesn't exist, otherwise returning the existing
+// value.
+func (s *CacheServer) SetNX(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SETNX
+	return s.Call(ctx, in)
+}
+
+// GetRange returns a range of bytes from a value.
+func (s *CacheServer) GetRange(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_GETRANGE
+	return s.Call(ctx, in)
+}
+
+// SetRange overwrites part of a value.
+func (s *CacheServer) SetRange(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_SETRANGE
+	return s.Call(ctx, in)
+}
+
+// StrLen returns the length of a value.
+func (s *CacheServer) StrLen(ctx context.Context, in *pb.CacheRequest) (*pb.CacheResponse, error) {
+	in.Operation = pb.CacheRequest_STRLEN
+	return s.Call(ctx, in)
+}
+
+// Stats returns the number of cached items along with pub/sub and watch
+// subscriber counts.
+func (s *CacheServer) Stats(ctx context.Context, in *pb.StatsRequest) (*pb.StatsResponse, error) {
+	s.cache.RLock()
+	items := s.cache.Len()
+	bytes := s.cache.Bytes()
+	s.cache.RUnlock()
+	subscribers, channels, patterns := s.pubsub.stats()
+	return &pb.StatsResponse{
+		Items:       uint64(items),
+		Subscribers: uint64(subscribers),
+		Channels:    uint64(channels),
+		Patterns:    uint64(patterns),
+		Watchers:    uint64(s.watchers.len()),
+		Bytes:       uint64(bytes),
+	}, nil
+}
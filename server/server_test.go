@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net"
+	"runtime"
+	"strconv"
 	"testing"
+	"time"
 
 	"reflect"
 
@@ -244,6 +248,168 @@ func TestPrepend(t *testing.T) {
 	testGet(t, cc, "doof", "yeahcha", codes.OK)
 }
 
+func TestCASGuarded(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+	testSet(t, cc, "guarded", "cha")
+	resp, err := cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded"}})
+	if err != nil {
+		t.Fatalf("got an error getting an item: %s", err)
+	}
+	cas := resp.Item.Cas
+
+	stale := &pb.CacheItem{Key: "guarded", Cas: cas + 100}
+	for _, op := range []pb.CacheRequest_Operation{pb.CacheRequest_DELETE, pb.CacheRequest_TOUCH, pb.CacheRequest_APPEND, pb.CacheRequest_PREPEND} {
+		_, err := cc.Call(ctx, &pb.CacheRequest{Operation: op, Item: stale, Append: []byte("x"), Prepend: []byte("x")})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition for %s with a stale CAS: %v", op, err)
+		}
+	}
+	testGet(t, cc, "guarded", "cha", codes.OK)
+
+	_, err = cc.Append(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded", Cas: cas}, Append: []byte("yeah")})
+	if err != nil {
+		t.Fatalf("got an error appending with the current CAS: %s", err)
+	}
+	testGet(t, cc, "guarded", "chayeah", codes.OK)
+
+	// the append changed the CAS
+	_, err = cc.Delete(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded", Cas: cas}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition deleting with the old CAS: %v", err)
+	}
+	resp, _ = cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded"}})
+	_, err = cc.Delete(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded", Cas: resp.Item.Cas}})
+	if err != nil {
+		t.Fatalf("got an error deleting with the current CAS: %s", err)
+	}
+	testGet(t, cc, "guarded", "", codes.NotFound)
+
+	_, err = cc.Delete(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded", Cas: cas}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound deleting a missing item with a CAS: %v", err)
+	}
+	if _, err = cc.Delete(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "guarded"}}); err != nil {
+		t.Fatalf("an unconditional delete of a missing item should succeed: %s", err)
+	}
+}
+
+func TestMutationResults(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+	item := &pb.CacheItem{Key: "results", Value: []byte("cha")}
+
+	requests := []*pb.CacheRequest{
+		{Operation: pb.CacheRequest_SET, Item: item},
+		{Operation: pb.CacheRequest_REPLACE, Item: item},
+		{Operation: pb.CacheRequest_APPEND, Item: item, Append: []byte("yeah")},
+		{Operation: pb.CacheRequest_PREPEND, Item: item, Prepend: []byte("oh")},
+		{Operation: pb.CacheRequest_TOUCH, Item: item},
+	}
+	var last uint64
+	for _, req := range requests {
+		resp, err := cc.Call(ctx, req)
+		if err != nil {
+			t.Fatalf("got an error from %s: %s", req.Operation, err)
+		}
+		gets, _ := cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "results"}})
+		if resp.Item.Cas == 0 || resp.Item.Cas == last || resp.Item.Cas != gets.Item.Cas {
+			t.Fatalf("%s returned CAS %d, the item has %d", req.Operation, resp.Item.Cas, gets.Item.Cas)
+		}
+		last = resp.Item.Cas
+	}
+
+	resp, err := cc.Cas(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "results", Value: []byte("new"), Cas: last}})
+	if err != nil || resp.Item.Cas <= last {
+		t.Fatalf("expected CAS to return a new CAS: %v %v", resp, err)
+	}
+	resp, err = cc.Add(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "added", Value: []byte("new")}})
+	if err != nil || resp.Item.Cas == 0 {
+		t.Fatalf("expected ADD to return a CAS: %v %v", resp, err)
+	}
+
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "counter", Value: lru.Uint64ToBytes(10)}})
+	resp, err = cc.Increment(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "counter"}, Increment: 5})
+	if n, _ := lru.BytesToUint64(resp.GetItem().GetValue()); err != nil || n != 15 || resp.Item.Cas == 0 {
+		t.Fatalf("expected INCREMENT to return 15 and a CAS: %v %v", resp, err)
+	}
+	resp, err = cc.Decrement(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "counter"}, Decrement: 7})
+	if n, _ := lru.BytesToUint64(resp.GetItem().GetValue()); err != nil || n != 8 {
+		t.Fatalf("expected DECREMENT to return 8: %v %v", resp, err)
+	}
+}
+
+func TestGetSetDel(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	resp, err := cc.GetSet(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "swap", Value: []byte("one")}})
+	if err != nil || resp.Number != 0 || len(resp.Item.Value) != 0 {
+		t.Fatalf("GETSET of a new item: %v %v", resp, err)
+	}
+	resp, err = cc.GetSet(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "swap", Value: []byte("two")}})
+	if err != nil || resp.Number != 1 || string(resp.Item.Value) != "one" {
+		t.Fatalf("expected GETSET to return the old value: %v %v", resp, err)
+	}
+	testGet(t, cc, "swap", "two", codes.OK)
+
+	resp, err = cc.SetNX(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "swap", Value: []byte("three")}})
+	if err != nil || resp.Number != 1 || string(resp.Item.Value) != "two" {
+		t.Fatalf("expected SETNX to return the existing value: %v %v", resp, err)
+	}
+	testGet(t, cc, "swap", "two", codes.OK)
+	resp, err = cc.SetNX(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "token", Value: []byte("once")}})
+	if err != nil || resp.Number != 0 {
+		t.Fatalf("expected SETNX to set a new item: %v %v", resp, err)
+	}
+
+	resp, err = cc.GetDel(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "token"}})
+	if err != nil || string(resp.Item.Value) != "once" {
+		t.Fatalf("expected GETDEL to return the value: %v %v", resp, err)
+	}
+	_, err = cc.GetDel(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "token"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound consuming a token twice: %v", err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+	testSet(t, cc, "log", "line one\n")
+	cc.Append(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log"}, Append: []byte("line two\n")})
+
+	resp, err := cc.GetRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log"}, Start: -9, Stop: -1})
+	if err != nil || string(resp.Item.Value) != "line two\n" {
+		t.Fatalf("expected GETRANGE to return the tail: %v %v", resp, err)
+	}
+
+	resp, err = cc.SetRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log", Value: []byte("ONE")}, Start: 5})
+	if err != nil || resp.Number != 18 || resp.Item.Cas == 0 {
+		t.Fatalf("expected SETRANGE to return the length and CAS: %v %v", resp, err)
+	}
+	testGet(t, cc, "log", "line ONE\nline two\n", codes.OK)
+
+	resp, err = cc.SetRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "sparse", Value: []byte("x")}, Start: 2})
+	if err != nil || resp.Number != 3 {
+		t.Fatalf("expected SETRANGE to zero pad a new value: %v %v", resp, err)
+	}
+	testGet(t, cc, "sparse", "\x00\x00x", codes.OK)
+
+	resp, err = cc.StrLen(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log"}})
+	if err != nil || resp.Number != 18 {
+		t.Fatalf("expected STRLEN to return 18: %v %v", resp, err)
+	}
+	_, err = cc.SetRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log", Value: []byte("x")}, Start: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a negative offset: %v", err)
+	}
+	_, err = cc.SetRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "log", Value: []byte("hello")}, Start: math.MaxInt64 - 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a huge offset: %v", err)
+	}
+}
+
 func TestIncrement(t *testing.T) {
 	cc := testSetup(20)
 	v := lru.Uint64ToBytes(20)
@@ -337,6 +503,50 @@ func TestNoop(t *testing.T) {
 	}
 }
 
+func TestInspect(t *testing.T) {
+	cc := testSetup(20)
+	setRequest := &pb.CacheRequest{Item: &pb.CacheItem{Key: "foo", Value: []byte("bar"), Ttl: 60}}
+	if _, err := cc.Set(context.Background(), setRequest); err != nil {
+		t.Fatalf("error setting item: %s", err)
+	}
+
+	inspectRequest := &pb.CacheRequest{Item: &pb.CacheItem{Key: "foo"}}
+	inspectResponse, err := cc.Inspect(context.Background(), inspectRequest)
+	if err != nil {
+		t.Fatalf("error inspecting item: %s", err)
+	}
+	info := inspectResponse.Info
+	if info.Size != 3 || info.Cas != 1 || info.TtlMs == 0 || info.TtlMs > 60000 {
+		t.Fatalf("info doesn't look right: %v", info)
+	}
+
+	_, err = cc.Inspect(context.Background(), &pb.CacheRequest{Item: &pb.CacheItem{Key: "nope"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound inspecting a missing item: %v", err)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	cc := testSetup(20)
+	setRequest := &pb.CacheRequest{Item: &pb.CacheItem{Key: "peek", Value: []byte("bar")}}
+	if _, err := cc.Set(context.Background(), setRequest); err != nil {
+		t.Fatalf("error setting item: %s", err)
+	}
+
+	peekResponse, err := cc.Peek(context.Background(), &pb.CacheRequest{Item: &pb.CacheItem{Key: "peek"}})
+	if err != nil {
+		t.Fatalf("error peeking item: %s", err)
+	}
+	if !bytes.Equal(peekResponse.Item.Value, []byte("bar")) || peekResponse.Item.Cas == 0 {
+		t.Fatalf("peeked item doesn't look right: %v", peekResponse.Item)
+	}
+
+	_, err = cc.Peek(context.Background(), &pb.CacheRequest{Item: &pb.CacheItem{Key: "nope"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound peeking a missing item: %v", err)
+	}
+}
+
 func TestStream(t *testing.T) {
 	cc := testSetup(20)
 
@@ -366,3 +576,975 @@ func TestStream(t *testing.T) {
 	stream.CloseSend()
 	<-waitc
 }
+
+func TestStreamErrors(t *testing.T) {
+	cc := testSetup(20)
+
+	stream, err := cc.Stream(context.Background())
+	if err != nil {
+		t.Fatalf("error acquiring stream: %v", err)
+	}
+	requests := []*pb.CacheRequest{
+		{Operation: pb.CacheRequest_GET, Item: &pb.CacheItem{Key: "stream-miss"}, RequestId: []byte("1")},
+		{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: "stream-hit", Value: []byte("v")}, RequestId: []byte("2")},
+		{Operation: pb.CacheRequest_GET, Item: &pb.CacheItem{Key: "stream-hit"}, RequestId: []byte("3")},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.CloseSend()
+
+	// the miss doesn't end the stream. responses for different keys can come
+	// back in any order
+	responses := make(map[string]*pb.CacheResponse)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("expected the stream to survive a miss: %v", err)
+		}
+		responses[string(resp.RequestId)] = resp
+	}
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if responses["1"].Code != uint32(codes.NotFound) || responses["1"].Message == "" {
+		t.Fatalf("expected NotFound for the miss: %v", responses["1"])
+	}
+	if responses["3"].Code != 0 || string(responses["3"].Item.Value) != "v" {
+		t.Fatalf("expected the hit: %v", responses["3"])
+	}
+}
+
+func TestStreamPipelined(t *testing.T) {
+	cc := testSetup(0)
+	ctx := context.Background()
+
+	stream, err := cc.Stream(ctx)
+	if err != nil {
+		t.Fatalf("error acquiring stream: %v", err)
+	}
+	// far more requests than the window, spread over a few keys
+	const n = 1000
+	go func() {
+		for i := 0; i < n; i++ {
+			key := fmt.Sprintf("pipelined-%d", i%4)
+			stream.Send(&pb.CacheRequest{
+				Operation: pb.CacheRequest_RPUSH,
+				Item:      &pb.CacheItem{Key: key},
+				Values:    [][]byte{[]byte(strconv.Itoa(i))},
+				RequestId: []byte(strconv.Itoa(i)),
+			})
+		}
+		stream.CloseSend()
+	}()
+	seen := make(map[string]bool)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[string(resp.RequestId)] = true
+	}
+	if len(seen) != n {
+		t.Fatalf("expected %d responses, got %d", n, len(seen))
+	}
+
+	// each key's pushes ran in the order they were sent
+	for k := 0; k < 4; k++ {
+		resp, err := cc.LRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: fmt.Sprintf("pipelined-%d", k)}, Start: 0, Stop: -1})
+		if err != nil || len(resp.Values) != n/4 {
+			t.Fatalf("expected %d values: %v", n/4, err)
+		}
+		for j, value := range resp.Values {
+			if string(value) != strconv.Itoa(j*4+k) {
+				t.Fatalf("expected %d at %d, got %s", j*4+k, j, value)
+			}
+		}
+	}
+}
+
+func TestStreamBarriers(t *testing.T) {
+	// streams have a lane per P, so make sure there are several to race
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	cc := testSetup(0)
+	ctx := context.Background()
+
+	stream, err := cc.Stream(ctx)
+	if err != nil {
+		t.Fatalf("error acquiring stream: %v", err)
+	}
+	const n = 100
+	go func() {
+		for i := 0; i < n; i++ {
+			stream.Send(&pb.CacheRequest{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: fmt.Sprintf("barrier-%d", i), Value: []byte("before")}})
+		}
+		stream.Send(&pb.CacheRequest{Operation: pb.CacheRequest_FLUSHALL})
+		for i := 0; i < n; i++ {
+			stream.Send(&pb.CacheRequest{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: fmt.Sprintf("barrier-%d", i%2), Value: []byte(strconv.Itoa(i))}})
+			stream.Send(&pb.CacheRequest{Operation: pb.CacheRequest_SADD, Item: &pb.CacheItem{Key: fmt.Sprintf("barrier-set-%d", i)}, Values: [][]byte{[]byte("x")}})
+		}
+		stream.Send(&pb.CacheRequest{
+			Operation: pb.CacheRequest_SINTER,
+			Item:      &pb.CacheItem{Key: "barrier-set-0"},
+			Keys:      []string{"barrier-set-1", fmt.Sprintf("barrier-set-%d", n-1)},
+			RequestId: []byte("sinter"),
+		})
+		stream.CloseSend()
+	}()
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the sets it reads were all added before it ran
+		if string(resp.RequestId) == "sinter" && (len(resp.Values) != 1 || string(resp.Values[0]) != "x") {
+			t.Fatalf("expected the intersection to be x: %v", resp)
+		}
+	}
+
+	// the flush ran after every set before it and before every set after it
+	for i := 2; i < n; i++ {
+		if _, err := cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: fmt.Sprintf("barrier-%d", i)}}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected barrier-%d to be flushed: %v", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: fmt.Sprintf("barrier-%d", i)}})
+		if err != nil || string(resp.Item.Value) != strconv.Itoa(n-2+i) {
+			t.Fatalf("expected barrier-%d to be %d: %v %v", i, n-2+i, resp, err)
+		}
+	}
+}
+
+func TestStreamQuiet(t *testing.T) {
+	cc := testSetup(0)
+	ctx := context.Background()
+
+	stream, err := cc.Stream(ctx)
+	if err != nil {
+		t.Fatalf("error acquiring stream: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		stream.Send(&pb.CacheRequest{
+			Operation: pb.CacheRequest_SET,
+			Item:      &pb.CacheItem{Key: fmt.Sprintf("quiet-%d", i), Value: []byte("v")},
+			Quiet:     true,
+		})
+	}
+	// errors are still answered
+	stream.Send(&pb.CacheRequest{
+		Operation: pb.CacheRequest_ADD,
+		Item:      &pb.CacheItem{Key: "quiet-0", Value: []byte("v")},
+		Quiet:     true,
+		RequestId: []byte("add"),
+	})
+	// and so are the changes that return data
+	stream.Send(&pb.CacheRequest{
+		Operation: pb.CacheRequest_GETDEL,
+		Item:      &pb.CacheItem{Key: "quiet-1"},
+		Quiet:     true,
+		RequestId: []byte("getdel"),
+	})
+	stream.Send(&pb.CacheRequest{Operation: pb.CacheRequest_NOOP, RequestId: []byte("flush")})
+
+	// the two are on different keys, so they can be answered in either order
+	answered := map[string]*pb.CacheResponse{}
+	for i := 0; i < 2; i++ {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error receiving: %v", err)
+		}
+		answered[string(resp.RequestId)] = resp
+	}
+	if resp := answered["add"]; resp == nil || resp.Code != uint32(codes.AlreadyExists) {
+		t.Fatalf("expected the failed add before the flush: %v", answered)
+	}
+	if resp := answered["getdel"]; resp == nil || resp.Code != 0 || string(resp.GetItem().GetValue()) != "v" {
+		t.Fatalf("expected the deleted value before the flush: %v", answered)
+	}
+	resp, err := stream.Recv()
+	if err != nil || string(resp.RequestId) != "flush" {
+		t.Fatalf("expected the flush marker: %v %v", resp, err)
+	}
+	// the flush comes after every quiet set has run
+	for i := 2; i < 100; i++ {
+		if _, err := cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: fmt.Sprintf("quiet-%d", i)}}); err != nil {
+			t.Fatalf("expected quiet-%d to be set: %v", i, err)
+		}
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expected no more responses: %v", err)
+	}
+}
+
+func TestMeta(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	_, err := cc.MetaGet(ctx, &pb.MetaRequest{Key: "meta"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing item: %v", err)
+	}
+	resp, err := cc.MetaGet(ctx, &pb.MetaRequest{Key: "meta", Quiet: true})
+	if err != nil || !resp.Miss {
+		t.Fatalf("expected a quiet miss: %v %v", resp, err)
+	}
+	resp, err = cc.MetaGet(ctx, &pb.MetaRequest{Key: "meta", Vivify: true, VivifyTtl: 30})
+	if err != nil || !resp.Win {
+		t.Fatalf("expected vivify to win: %v %v", resp, err)
+	}
+
+	resp, err = cc.MetaSet(ctx, &pb.MetaRequest{Key: "meta", Value: []byte("bar"), Ttl: 60, ReturnCas: true})
+	if err != nil || resp.Cas == 0 {
+		t.Fatalf("error setting item: %v %v", resp, err)
+	}
+	_, err = cc.MetaSet(ctx, &pb.MetaRequest{Key: "meta", SetMode: pb.MetaRequest_ADD})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists adding an existing item: %v", err)
+	}
+
+	resp, err = cc.MetaGet(ctx, &pb.MetaRequest{Key: "meta", ReturnValue: true, ReturnTtl: true, ReturnSize: true})
+	if err != nil || !bytes.Equal(resp.Value, []byte("bar")) || resp.Size != 3 || resp.Ttl <= 0 || resp.Ttl > 60 || resp.Cas != 0 {
+		t.Fatalf("meta get response doesn't look right: %v %v", resp, err)
+	}
+
+	if _, err = cc.MetaDelete(ctx, &pb.MetaRequest{Key: "meta", Invalidate: true}); err != nil {
+		t.Fatalf("error invalidating item: %v", err)
+	}
+	resp, _ = cc.MetaGet(ctx, &pb.MetaRequest{Key: "meta"})
+	if !resp.Stale || !resp.Win {
+		t.Fatalf("expected a stale item and win: %v", resp)
+	}
+
+	resp, err = cc.MetaArithmetic(ctx, &pb.MetaRequest{Key: "meta-counter", Vivify: true, Initial: 5})
+	if err != nil || resp.Number != 5 {
+		t.Fatalf("expected a vivified counter: %v %v", resp, err)
+	}
+	resp, err = cc.MetaArithmetic(ctx, &pb.MetaRequest{Key: "meta-counter", ArithmeticMode: pb.MetaRequest_DECREMENT, Delta: 2})
+	if err != nil || resp.Number != 3 {
+		t.Fatalf("expected the counter to be 3: %v %v", resp, err)
+	}
+
+	// every meta command that takes a CAS reports a mismatch the same way
+	for cmd, call := range map[string]func(*pb.MetaRequest) (*pb.MetaResponse, error){
+		"MetaSet": func(in *pb.MetaRequest) (*pb.MetaResponse, error) {
+			return cc.MetaSet(ctx, in)
+		},
+		"MetaDelete": func(in *pb.MetaRequest) (*pb.MetaResponse, error) {
+			return cc.MetaDelete(ctx, in)
+		},
+		"MetaArithmetic": func(in *pb.MetaRequest) (*pb.MetaResponse, error) {
+			return cc.MetaArithmetic(ctx, in)
+		},
+	} {
+		_, err = call(&pb.MetaRequest{Key: "meta-counter", Value: []byte("x"), Cas: 1})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition for %s with a stale CAS: %v", cmd, err)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	cc := testSetup(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := cc.Watch(ctx, &pb.WatchRequest{Keys: []string{"w-one"}, Prefixes: []string{"w-p"}})
+	if err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+	// keep poking w-one until the watch is registered and sees it
+	synced := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-synced:
+				return
+			default:
+				cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "w-one", Value: []byte("sync")}})
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+	if event, err := stream.Recv(); err != nil || event.Type != pb.WatchEvent_SET || event.Key != "w-one" {
+		t.Fatalf("expected a set event for w-one: %v %v", event, err)
+	}
+	close(synced)
+	time.Sleep(time.Millisecond * 5)
+	cc.Delete(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "w-one"}})
+
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "unwatched", Value: []byte("x")}})
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "w-prefixed", Value: []byte("x")}})
+	getsResponse, _ := cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "w-prefixed"}})
+	cc.Touch(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "w-prefixed"}})
+	// with a max of 2 entries, these evict w-prefixed
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "a", Value: []byte("x")}})
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "b", Value: []byte("x")}})
+	cc.FlushAll(ctx, &pb.CacheRequest{})
+
+	// skip any leftover events from the sync loop
+	event, err := stream.Recv()
+	for err == nil && event.Key == "w-one" && event.Type == pb.WatchEvent_SET {
+		event, err = stream.Recv()
+	}
+	if err != nil || event.Type != pb.WatchEvent_DELETE || event.Key != "w-one" {
+		t.Fatalf("expected a delete event for w-one: %v %v", event, err)
+	}
+
+	expected := []pb.WatchEvent_Type{pb.WatchEvent_SET, pb.WatchEvent_TOUCH, pb.WatchEvent_EVICT, pb.WatchEvent_FLUSH}
+	for i, eventType := range expected {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error receiving event: %v", err)
+		}
+		if event.Type != eventType || (eventType != pb.WatchEvent_FLUSH && event.Key != "w-prefixed") {
+			t.Fatalf("expected a %s event for w-prefixed: %v", eventType, event)
+		}
+		if i == 0 && event.Cas != getsResponse.Item.Cas {
+			t.Fatalf("expected the set event to carry the new cas %d: %v", getsResponse.Item.Cas, event)
+		}
+		if i == 1 && event.Cas <= getsResponse.Item.Cas {
+			t.Fatalf("expected the touch event to carry a newer cas: %v", event)
+		}
+	}
+}
+
+func TestWatchUnchanged(t *testing.T) {
+	cc := testSetup(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := cc.Watch(ctx, &pb.WatchRequest{Prefixes: []string{"u-"}})
+	if err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+	// keep poking u-sync until the watch is registered and sees it
+	synced := make(chan struct{})
+	poked := make(chan struct{})
+	go func() {
+		defer close(poked)
+		for {
+			select {
+			case <-synced:
+				return
+			default:
+				cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "u-sync", Value: []byte("sync")}})
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error receiving the sync event: %v", err)
+	}
+	close(synced)
+	<-poked
+
+	calls := []*pb.CacheRequest{
+		{Operation: pb.CacheRequest_DELETE, Item: &pb.CacheItem{Key: "u-missing"}},
+		{Operation: pb.CacheRequest_HDEL, Item: &pb.CacheItem{Key: "u-missing"}, Fields: []*pb.HashField{{Field: "f"}}},
+		{Operation: pb.CacheRequest_LTRIM, Item: &pb.CacheItem{Key: "u-missing"}, Start: 0, Stop: 1},
+		{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: "u-value", Value: []byte("a")}},
+		{Operation: pb.CacheRequest_SETNX, Item: &pb.CacheItem{Key: "u-value", Value: []byte("b")}},
+		{Operation: pb.CacheRequest_HSET, Item: &pb.CacheItem{Key: "u-hash"}, Fields: []*pb.HashField{{Field: "f", Value: []byte("v")}}},
+		{Operation: pb.CacheRequest_HDEL, Item: &pb.CacheItem{Key: "u-hash"}, Fields: []*pb.HashField{{Field: "g"}}},
+		{Operation: pb.CacheRequest_SADD, Item: &pb.CacheItem{Key: "u-set"}, Values: [][]byte{[]byte("x")}},
+		{Operation: pb.CacheRequest_SREM, Item: &pb.CacheItem{Key: "u-set"}, Values: [][]byte{[]byte("y")}},
+		{Operation: pb.CacheRequest_ZADD, Item: &pb.CacheItem{Key: "u-zset"}, Scored: []*pb.ScoredMember{{Member: []byte("x"), Score: 1}}},
+		{Operation: pb.CacheRequest_ZREM, Item: &pb.CacheItem{Key: "u-zset"}, Values: [][]byte{[]byte("y")}},
+	}
+	for _, in := range calls {
+		if _, err := cc.Call(ctx, in); err != nil {
+			t.Fatalf("error calling %s: %v", in.Operation, err)
+		}
+	}
+	// vivifying creates the item, but the second client just sees it
+	for i := 0; i < 2; i++ {
+		if _, err := cc.MetaGet(ctx, &pb.MetaRequest{Key: "u-vivified", Vivify: true, VivifyTtl: 30}); err != nil {
+			t.Fatalf("error vivifying: %v", err)
+		}
+	}
+	if _, err := cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "u-end", Value: []byte("end")}}); err != nil {
+		t.Fatalf("error setting u-end: %v", err)
+	}
+
+	// only the calls that changed something are published
+	expected := []string{"u-value", "u-hash", "u-set", "u-zset", "u-vivified", "u-end"}
+	for _, key := range expected {
+		event, err := stream.Recv()
+		for err == nil && event.Key == "u-sync" {
+			event, err = stream.Recv()
+		}
+		if err != nil || event.Type != pb.WatchEvent_SET || event.Key != key {
+			t.Fatalf("expected a set event for %s: %v %v", key, event, err)
+		}
+	}
+}
+
+func TestWatchDropped(t *testing.T) {
+	ws := newWatchers()
+	w := ws.add(&pb.WatchRequest{Buffer: 2})
+	for i := 0; i < 5; i++ {
+		ws.publish(&pb.WatchEvent{Key: fmt.Sprintf("key-%d", i)})
+	}
+	<-w.events
+	<-w.events
+	ws.publish(&pb.WatchEvent{Key: "last"})
+	if event := <-w.events; event.Key != "last" || event.Dropped != 3 {
+		t.Fatalf("expected the next event to report 3 dropped: %v", event)
+	}
+
+	ws.close()
+	if _, ok := <-w.events; ok {
+		t.Fatal("expected close to end the watch")
+	}
+}
+
+func TestPubSub(t *testing.T) {
+	cc := testSetup(20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := cc.Publish(ctx, &pb.PublishRequest{Channel: "news", Message: []byte("nobody")}); err != nil {
+		t.Fatalf("error publishing: %v", err)
+	}
+
+	stream, err := cc.Subscribe(ctx, &pb.SubscribeRequest{Channels: []string{"news"}, Patterns: []string{"invalidate.*"}})
+	if err != nil {
+		t.Fatalf("error subscribing: %v", err)
+	}
+	// wait for the subscription to register
+	for {
+		stats, err := cc.Stats(ctx, &pb.StatsRequest{})
+		if err != nil {
+			t.Fatalf("error getting stats: %v", err)
+		}
+		if stats.Subscribers == 1 {
+			if stats.Channels != 1 || stats.Patterns != 1 {
+				t.Fatalf("stats don't look right: %v", stats)
+			}
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	receivers := map[string]uint64{"news": 1, "sports": 0, "invalidate.users": 1}
+	for _, channel := range []string{"news", "sports", "invalidate.users"} {
+		resp, err := cc.Publish(ctx, &pb.PublishRequest{Channel: channel, Message: []byte(channel)})
+		if err != nil {
+			t.Fatalf("error publishing: %v", err)
+		}
+		if resp.Receivers != receivers[channel] {
+			t.Fatalf("expected %d receivers for %s, got %d", receivers[channel], channel, resp.Receivers)
+		}
+	}
+	msg, err := stream.Recv()
+	if err != nil || msg.Channel != "news" || string(msg.Message) != "news" || msg.Pattern != "" {
+		t.Fatalf("expected the news message: %v %v", msg, err)
+	}
+	msg, err = stream.Recv()
+	if err != nil || msg.Channel != "invalidate.users" || msg.Pattern != "invalidate.*" {
+		t.Fatalf("expected the pattern message: %v %v", msg, err)
+	}
+
+	// stream errors show up on the first receive
+	stream, err = cc.Subscribe(ctx, &pb.SubscribeRequest{Patterns: []string{"[bad"}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a bad pattern: %v", err)
+	}
+}
+
+func TestPubSubSlowPolicy(t *testing.T) {
+	ps := newPubsub()
+	dropper, _ := ps.add(&pb.SubscribeRequest{Channels: []string{"c"}, Buffer: 1})
+	disconnecter, _ := ps.add(&pb.SubscribeRequest{Channels: []string{"c"}, Buffer: 1, SlowPolicy: pb.SubscribeRequest_DISCONNECT})
+
+	if receivers := ps.publish("c", []byte("1")); receivers != 2 {
+		t.Fatalf("expected 2 receivers, got %d", receivers)
+	}
+	if receivers := ps.publish("c", []byte("2")); receivers != 0 {
+		t.Fatalf("expected 0 receivers with full buffers, got %d", receivers)
+	}
+	select {
+	case <-disconnecter.slow:
+	default:
+		t.Fatal("expected the slow subscriber to be disconnected")
+	}
+	if subscribers, _, _ := ps.stats(); subscribers != 1 {
+		t.Fatalf("expected 1 subscriber left, got %d", subscribers)
+	}
+
+	<-dropper.messages
+	ps.publish("c", []byte("3"))
+	if msg := <-dropper.messages; string(msg.Message) != "3" || msg.Dropped != 1 {
+		t.Fatalf("expected message 3 with 1 dropped: %v", msg)
+	}
+}
+
+func TestHash(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+	item := &pb.CacheItem{Key: "hash"}
+
+	resp, err := cc.HSet(ctx, &pb.CacheRequest{Item: item, Fields: []*pb.HashField{
+		{Field: "b", Value: []byte("2")},
+		{Field: "a", Value: []byte("1")},
+	}})
+	if err != nil || resp.Number != 2 {
+		t.Fatalf("expected 2 fields added: %v %v", resp, err)
+	}
+	resp, err = cc.HGet(ctx, &pb.CacheRequest{Item: item, Fields: []*pb.HashField{{Field: "a"}}})
+	if err != nil || string(resp.Item.Value) != "1" {
+		t.Fatalf("expected field a to be 1: %v %v", resp, err)
+	}
+	resp, err = cc.HIncrBy(ctx, &pb.CacheRequest{Item: item, Fields: []*pb.HashField{{Field: "a"}}, Delta: -5})
+	if err != nil || resp.Number != -4 {
+		t.Fatalf("expected field a to be -4: %v %v", resp, err)
+	}
+	_, err = cc.HIncrBy(ctx, &pb.CacheRequest{Item: item, Fields: []*pb.HashField{{Field: "a"}}, Delta: math.MinInt64})
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected OutOfRange for an overflow: %v", err)
+	}
+	resp, err = cc.HGetAll(ctx, &pb.CacheRequest{Item: item})
+	if err != nil || len(resp.Fields) != 2 || resp.Fields[0].Field != "a" || string(resp.Fields[0].Value) != "-4" {
+		t.Fatalf("unexpected fields: %v %v", resp, err)
+	}
+	resp, err = cc.HDel(ctx, &pb.CacheRequest{Item: item, Fields: []*pb.HashField{{Field: "b"}}})
+	if err != nil || resp.Number != 1 {
+		t.Fatalf("expected 1 field deleted: %v %v", resp, err)
+	}
+	if resp, _ = cc.HLen(ctx, &pb.CacheRequest{Item: item}); resp.Number != 1 {
+		t.Fatalf("expected 1 field left: %v", resp)
+	}
+
+	_, err = cc.Get(ctx, &pb.CacheRequest{Item: item})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition getting a hash: %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+	item := &pb.CacheItem{Key: "list"}
+
+	resp, err := cc.RPush(ctx, &pb.CacheRequest{Item: item, Values: [][]byte{[]byte("a"), []byte("b"), []byte("c")}})
+	if err != nil || resp.Number != 3 {
+		t.Fatalf("expected a length of 3: %v %v", resp, err)
+	}
+	resp, err = cc.LRange(ctx, &pb.CacheRequest{Item: item, Start: 0, Stop: -2})
+	if err != nil || len(resp.Values) != 2 || string(resp.Values[1]) != "b" {
+		t.Fatalf("unexpected range: %v %v", resp, err)
+	}
+	if resp, err = cc.RPop(ctx, &pb.CacheRequest{Item: item}); err != nil || string(resp.Item.Value) != "c" {
+		t.Fatalf("expected to pop c: %v %v", resp, err)
+	}
+	if resp, err = cc.BLPop(ctx, &pb.CacheRequest{Item: item}); err != nil || string(resp.Item.Value) != "a" {
+		t.Fatalf("expected to pop a without blocking: %v %v", resp, err)
+	}
+
+	// blocking pop on an empty list times out at the deadline
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	_, err = cc.BLPop(timeout, &pb.CacheRequest{Item: &pb.CacheItem{Key: "empty"}})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded: %v", err)
+	}
+
+	// and is woken by a push
+	popped := make(chan *pb.CacheResponse)
+	go func() {
+		resp, err := cc.BRPop(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "queue"}})
+		if err != nil {
+			t.Errorf("error popping: %v", err)
+		}
+		popped <- resp
+	}()
+	time.Sleep(time.Millisecond * 20)
+	cc.LPush(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "queue"}, Values: [][]byte{[]byte("job")}})
+	select {
+	case resp := <-popped:
+		if resp == nil || string(resp.Item.Value) != "job" {
+			t.Fatalf("expected to pop the pushed job: %v", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocking pop wasn't woken by the push")
+	}
+}
+
+func TestSetType(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	resp, err := cc.SAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Values: [][]byte{[]byte("a"), []byte("b")}})
+	if err != nil || resp.Number != 2 {
+		t.Fatalf("expected 2 members added: %v %v", resp, err)
+	}
+	cc.SAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s2"}, Values: [][]byte{[]byte("b"), []byte("c")}})
+	resp, err = cc.SIsMember(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Values: [][]byte{[]byte("a")}})
+	if err != nil || resp.Number != 1 {
+		t.Fatalf("expected a to be a member: %v %v", resp, err)
+	}
+	resp, err = cc.SUnion(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Keys: []string{"s2"}})
+	if err != nil || len(resp.Values) != 3 {
+		t.Fatalf("expected a union of 3: %v %v", resp, err)
+	}
+	resp, err = cc.SInter(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Keys: []string{"s2"}})
+	if err != nil || len(resp.Values) != 1 || string(resp.Values[0]) != "b" {
+		t.Fatalf("expected an intersection of b: %v %v", resp, err)
+	}
+	resp, err = cc.SDiff(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Keys: []string{"s2"}})
+	if err != nil || len(resp.Values) != 1 || string(resp.Values[0]) != "a" {
+		t.Fatalf("expected a difference of a: %v %v", resp, err)
+	}
+	cc.SRem(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}, Values: [][]byte{[]byte("a")}})
+	if resp, _ = cc.SCard(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}}); resp.Number != 1 {
+		t.Fatalf("expected 1 member left: %v", resp)
+	}
+	if resp, _ = cc.SMembers(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "s1"}}); len(resp.Values) != 1 {
+		t.Fatalf("expected 1 member left: %v", resp)
+	}
+}
+
+func TestZset(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	scored := []*pb.ScoredMember{{Member: []byte("a"), Score: 3}, {Member: []byte("b"), Score: 1}, {Member: []byte("c"), Score: 2}}
+	resp, err := cc.ZAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Scored: scored})
+	if err != nil || resp.Number != 3 {
+		t.Fatalf("expected 3 members added: %v %v", resp, err)
+	}
+	resp, err = cc.ZRange(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Start: 0, Stop: -1, Reverse: true})
+	if err != nil || len(resp.Scored) != 3 || string(resp.Scored[0].Member) != "a" || resp.Scored[0].Score != 3 {
+		t.Fatalf("expected a first in reverse: %v %v", resp, err)
+	}
+	resp, err = cc.ZRangeByScore(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Min: 1.5, Max: 3})
+	if err != nil || len(resp.Scored) != 2 || string(resp.Scored[0].Member) != "c" {
+		t.Fatalf("expected c and a by score: %v %v", resp, err)
+	}
+	resp, err = cc.ZIncrBy(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Scored: []*pb.ScoredMember{{Member: []byte("b"), Score: 5}}})
+	if err != nil || resp.Score != 6 {
+		t.Fatalf("expected b's score to be 6: %v %v", resp, err)
+	}
+	resp, err = cc.ZRank(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Values: [][]byte{[]byte("b")}})
+	if err != nil || resp.Number != 2 {
+		t.Fatalf("expected b at rank 2: %v %v", resp, err)
+	}
+	_, err = cc.ZRank(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Values: [][]byte{[]byte("nope")}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing member: %v", err)
+	}
+	cc.ZRem(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Values: [][]byte{[]byte("a")}})
+	if resp, _ = cc.ZCard(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}}); resp.Number != 2 {
+		t.Fatalf("expected 2 members left: %v", resp)
+	}
+	_, err = cc.ZAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "z"}, Scored: []*pb.ScoredMember{{Member: []byte("nan"), Score: math.NaN()}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a NaN score: %v", err)
+	}
+}
+
+func TestProbabilistic(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	resp, err := cc.PFAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "h1"}, Values: [][]byte{[]byte("a"), []byte("b")}})
+	if err != nil || resp.Number != 1 {
+		t.Fatalf("expected the HyperLogLog to change: %v %v", resp, err)
+	}
+	cc.PFAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "h2"}, Values: [][]byte{[]byte("b"), []byte("c")}})
+	resp, err = cc.PFCount(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "h1"}, Keys: []string{"h2"}})
+	if err != nil || resp.Number != 3 {
+		t.Fatalf("expected a count of 3: %v %v", resp, err)
+	}
+	cc.PFMerge(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "h3"}, Keys: []string{"h1", "h2"}})
+	if resp, _ = cc.PFCount(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "h3"}}); resp.Number != 3 {
+		t.Fatalf("expected a merged count of 3: %v", resp)
+	}
+
+	_, err = cc.BFReserve(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "bf"}, Capacity: 100, ErrorRate: 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a bad error rate: %v", err)
+	}
+	if _, err = cc.BFReserve(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "bf"}, Capacity: 100, ErrorRate: 0.01}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = cc.BFAdd(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "bf"}, Values: [][]byte{[]byte("x")}})
+	if err != nil || resp.Number != 1 {
+		t.Fatalf("expected x to be added: %v %v", resp, err)
+	}
+	if resp, _ = cc.BFExists(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "bf"}, Values: [][]byte{[]byte("x")}}); resp.Number != 1 {
+		t.Fatalf("expected x to exist: %v", resp)
+	}
+	resp, err = cc.BFMExists(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "bf"}, Values: [][]byte{[]byte("x"), []byte("y")}})
+	if err != nil || len(resp.Exists) != 2 || !resp.Exists[0] || resp.Exists[1] {
+		t.Fatalf("expected x but not y to exist: %v %v", resp, err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	in := &pb.RateLimitRequest{Key: "rl", Rate: 1, PeriodMs: 60000, Burst: 1}
+	for i := int64(1); i >= 0; i-- {
+		resp, err := cc.RateLimit(ctx, in)
+		if err != nil || !resp.Allowed || resp.Remaining != i {
+			t.Fatalf("expected an allowed request with %d remaining: %v %v", i, resp, err)
+		}
+	}
+	resp, err := cc.RateLimit(ctx, in)
+	if err != nil || resp.Allowed || resp.RetryAfterMs == 0 || resp.RetryAfterMs > 60000 {
+		t.Fatalf("expected a denied request: %v %v", resp, err)
+	}
+
+	// checking a fresh limit doesn't use any of it
+	check := &pb.RateLimitRequest{Key: "rl-check", Rate: 1, PeriodMs: 60000, Burst: 1, CheckOnly: true}
+	for i := 0; i < 3; i++ {
+		resp, err = cc.RateLimit(ctx, check)
+		if err != nil || !resp.Allowed || resp.Remaining != 2 {
+			t.Fatalf("expected a check with 2 remaining: %v %v", resp, err)
+		}
+	}
+	_, err = cc.RateLimit(ctx, &pb.RateLimitRequest{Key: "rl"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without a rate: %v", err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	held, err := cc.Lock(ctx, &pb.LockRequest{Key: "l", TtlMs: 60000})
+	if err != nil || held.Owner == "" {
+		t.Fatalf("expected to acquire the lock: %v %v", held, err)
+	}
+	_, err = cc.Lock(ctx, &pb.LockRequest{Key: "l", TtlMs: 60000})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists for a held lock: %v", err)
+	}
+	_, err = cc.Unlock(ctx, &pb.LockRequest{Key: "l", Owner: "nope"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition unlocking with the wrong owner: %v", err)
+	}
+	renewed, err := cc.Renew(ctx, &pb.LockRequest{Key: "l", Owner: held.Owner, TtlMs: 60000})
+	if err != nil || renewed.Fence != held.Fence {
+		t.Fatalf("expected renewing to keep the fence: %v %v", renewed, err)
+	}
+
+	// a waiter gets the lock when it's released
+	acquired := make(chan *pb.LockResponse)
+	go func() {
+		resp, err := cc.Lock(ctx, &pb.LockRequest{Key: "l", TtlMs: 60000, Wait: true})
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- resp
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if _, err := cc.Unlock(ctx, &pb.LockRequest{Key: "l", Owner: held.Owner}); err != nil {
+		t.Fatal(err)
+	}
+	if resp := <-acquired; resp == nil || resp.Fence <= held.Fence {
+		t.Fatalf("expected the waiter to get a larger fence than %d: %v", held.Fence, resp)
+	}
+
+	// or when it expires
+	cc.Lock(ctx, &pb.LockRequest{Key: "e", TtlMs: 50})
+	if _, err := cc.Lock(ctx, &pb.LockRequest{Key: "e", TtlMs: 60000, Wait: true}); err != nil {
+		t.Fatalf("expected to get the lock once it expired: %v", err)
+	}
+
+	// or gives up at the deadline
+	deadline, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = cc.Lock(deadline, &pb.LockRequest{Key: "e", TtlMs: 60000, Wait: true})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded waiting for a held lock: %v", err)
+	}
+
+	// the lock can only be read or removed through the lock RPCs
+	item := &pb.CacheItem{Key: "e", Value: []byte("stolen")}
+	for _, op := range []pb.CacheRequest_Operation{pb.CacheRequest_GET, pb.CacheRequest_PEEK, pb.CacheRequest_SET,
+		pb.CacheRequest_REPLACE, pb.CacheRequest_DELETE, pb.CacheRequest_TOUCH, pb.CacheRequest_APPEND, pb.CacheRequest_GETDEL} {
+		if _, err := cc.Call(ctx, &pb.CacheRequest{Operation: op, Item: item}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition for %s on a lock: %v", op, err)
+		}
+	}
+	if _, err := cc.MetaGet(ctx, &pb.MetaRequest{Key: "e"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition meta getting a lock: %v", err)
+	}
+
+	// a flush releases it, waking waiters right away
+	go func() {
+		resp, err := cc.Lock(ctx, &pb.LockRequest{Key: "e", TtlMs: 60000, Wait: true})
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- resp
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cc.FlushAll(ctx, &pb.CacheRequest{})
+	select {
+	case resp := <-acquired:
+		if resp == nil {
+			t.Fatal("expected the waiter to get the lock")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the flush to wake the waiter")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	enqueued, err := cc.Enqueue(ctx, &pb.QueueRequest{Key: "q", Body: []byte("job")})
+	if err != nil || enqueued.Id == 0 {
+		t.Fatalf("expected a message ID: %v %v", enqueued, err)
+	}
+	received, err := cc.Receive(ctx, &pb.QueueRequest{Key: "q", VisibilityMs: 60000})
+	if err != nil || received.Id != enqueued.Id || string(received.Body) != "job" || received.Receives != 1 {
+		t.Fatalf("expected to receive the job: %v %v", received, err)
+	}
+	_, err = cc.Receive(ctx, &pb.QueueRequest{Key: "q"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound while the job is hidden: %v", err)
+	}
+	if _, err := cc.Nack(ctx, &pb.QueueRequest{Key: "q", Receipt: received.Receipt}); err != nil {
+		t.Fatal(err)
+	}
+	received, err = cc.Receive(ctx, &pb.QueueRequest{Key: "q"})
+	if err != nil || received.Receives != 2 {
+		t.Fatalf("expected the nacked job again: %v %v", received, err)
+	}
+	if _, err := cc.Ack(ctx, &pb.QueueRequest{Key: "q", Receipt: received.Receipt}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cc.Ack(ctx, &pb.QueueRequest{Key: "q", Receipt: received.Receipt})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound acking twice: %v", err)
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "etag", Value: []byte("large value")}})
+	resp, err := cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "etag"}})
+	if err != nil || resp.NotModified {
+		t.Fatalf("expected the value: %v %v", resp, err)
+	}
+	cas := resp.Item.Cas
+
+	for _, op := range []pb.CacheRequest_Operation{pb.CacheRequest_GET, pb.CacheRequest_GETS} {
+		resp, err = cc.Call(ctx, &pb.CacheRequest{Operation: op, Item: &pb.CacheItem{Key: "etag"}, IfCasDiffers: cas})
+		if err != nil || !resp.NotModified || len(resp.Item.Value) != 0 || resp.Item.Cas != cas {
+			t.Fatalf("expected %s to be not modified: %v %v", op, resp, err)
+		}
+	}
+
+	cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "etag", Value: []byte("new value")}})
+	resp, err = cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "etag"}, IfCasDiffers: cas})
+	if err != nil || resp.NotModified || string(resp.Item.Value) != "new value" {
+		t.Fatalf("expected the new value: %v %v", resp, err)
+	}
+	_, err = cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "nope"}, IfCasDiffers: cas})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing item: %v", err)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	resp, err := cc.SetMulti(ctx, &pb.MultiRequest{Items: []*pb.CacheItem{
+		{Key: "m1", Value: []byte("one")},
+		{Key: "m2", Value: []byte("two")},
+	}})
+	if err != nil || len(resp.Responses) != 2 {
+		t.Fatalf("expected 2 results: %v %v", resp, err)
+	}
+	resp, err = cc.GetMulti(ctx, &pb.MultiRequest{Items: []*pb.CacheItem{{Key: "m1"}, {Key: "nope"}, {Key: "m2"}}})
+	if err != nil || len(resp.Responses) != 3 {
+		t.Fatalf("expected 3 results: %v %v", resp, err)
+	}
+	if string(resp.Responses[0].Item.Value) != "one" || resp.Responses[0].Item.Cas == 0 {
+		t.Fatalf("expected m1 with its CAS: %v", resp.Responses[0])
+	}
+	if resp.Responses[1].Code != uint32(codes.NotFound) || resp.Responses[1].Item.Key != "nope" {
+		t.Fatalf("expected a miss for nope: %v", resp.Responses[1])
+	}
+	if string(resp.Responses[2].Item.Value) != "two" {
+		t.Fatalf("expected m2: %v", resp.Responses[2])
+	}
+	cc.DeleteMulti(ctx, &pb.MultiRequest{Items: []*pb.CacheItem{{Key: "m1"}, {Key: "m2"}}})
+	resp, _ = cc.GetMulti(ctx, &pb.MultiRequest{Items: []*pb.CacheItem{{Key: "m1"}, {Key: "m2"}}})
+	for _, r := range resp.Responses {
+		if r.Code != uint32(codes.NotFound) {
+			t.Fatalf("expected the deleted items to miss: %v", r)
+		}
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	cc := testSetup(20)
+	ctx := context.Background()
+
+	set, _ := cc.Set(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "balance", Value: []byte("100")}})
+	gets, _ := cc.Gets(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: set.Item.Key}})
+
+	resp, err := cc.Transaction(ctx, &pb.TransactionRequest{
+		Watch: []*pb.CacheItem{{Key: "balance", Cas: gets.Item.Cas}, {Key: "missing"}},
+		Requests: []*pb.CacheRequest{
+			{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: "balance", Value: []byte("90")}},
+			{Operation: pb.CacheRequest_GET, Item: &pb.CacheItem{Key: "nope"}},
+			{Operation: pb.CacheRequest_RPUSH, Item: &pb.CacheItem{Key: "log"}, Values: [][]byte{[]byte("-10")}},
+			{Operation: pb.CacheRequest_GET, Item: &pb.CacheItem{Key: "balance"}},
+		},
+	})
+	if err != nil || resp.Aborted || len(resp.Responses) != 4 {
+		t.Fatalf("expected 4 results: %v %v", resp, err)
+	}
+	if resp.Responses[1].Code != uint32(codes.NotFound) || resp.Responses[1].Message == "" {
+		t.Fatalf("expected the missing key to fail with NotFound: %v", resp.Responses[1])
+	}
+	if resp.Responses[2].Code != 0 || resp.Responses[2].Number != 1 {
+		t.Fatalf("expected the push to succeed: %v", resp.Responses[2])
+	}
+	if string(resp.Responses[3].Item.Value) != "90" {
+		t.Fatalf("expected to read the transaction's own write: %v", resp.Responses[3])
+	}
+
+	// the CAS has changed, so running it again aborts
+	resp, err = cc.Transaction(ctx, &pb.TransactionRequest{
+		Watch: []*pb.CacheItem{{Key: "balance", Cas: gets.Item.Cas}},
+		Requests: []*pb.CacheRequest{
+			{Operation: pb.CacheRequest_SET, Item: &pb.CacheItem{Key: "balance", Value: []byte("80")}},
+		},
+	})
+	if err != nil || !resp.Aborted || resp.Conflict != "balance" || len(resp.Responses) != 0 {
+		t.Fatalf("expected the transaction to abort: %v %v", resp, err)
+	}
+	if got, _ := cc.Get(ctx, &pb.CacheRequest{Item: &pb.CacheItem{Key: "balance"}}); string(got.Item.Value) != "90" {
+		t.Fatalf("expected the aborted write not to happen: %v", got)
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"hash/fnv"
+	"io"
+	"runtime"
+	"sync"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+)
+
+// defaultStreamWindow is the number of requests a Stream runs at once unless
+// set with WithStreamWindow.
+const defaultStreamWindow = 64
+
+// Stream calls the cache operation for each request on the stream and sends
+// back its response. Failed operations are reported in their response's code
+// and message rather than ending the stream.
+//
+// Requests are read ahead and run concurrently, up to the server's stream
+// window at a time, after which reading waits for responses to be sent.
+// Requests for the same key run in the order they were sent, but otherwise
+// responses are sent as they're ready, so clients should match them up by
+// request_id. A NOOP waits for everything before it, so its response is a
+// marker that those have all been answered. FLUSHALL and requests naming
+// several keys, like SINTER or PFMERGE, span lanes, so they run on their own:
+// after everything before them and before anything after them.
+//
+// Successful quiet requests that change the cache aren't answered at all,
+// unless their response carries data, like the value LPOP removes.
+func (s *CacheServer) Stream(stream pb.Cache_StreamServer) error {
+	window := s.streamWindow
+	inflight := make(chan struct{}, window)
+	responses := make(chan *pb.CacheResponse, window)
+	// pending counts the requests that haven't been answered (or skipped, if
+	// quiet) yet
+	var pending sync.WaitGroup
+	done := func() {
+		<-inflight
+		pending.Done()
+	}
+
+	// each key always goes to the same lane, which runs its requests in order
+	lanes := make([]chan *pb.CacheRequest, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range lanes {
+		lanes[i] = make(chan *pb.CacheRequest, window)
+		wg.Add(1)
+		go func(lane chan *pb.CacheRequest) {
+			defer wg.Done()
+			for in := range lane {
+				resp, err := s.Call(stream.Context(), in)
+				if err == nil && in.Quiet && !readOnly(in.Operation) && !returnsData(in.Operation) {
+					done()
+					continue
+				}
+				responses <- embeddedResponse(resp, err, in)
+			}
+		}(lanes[i])
+	}
+
+	// Send isn't safe to call concurrently, so one goroutine sends everything.
+	// It keeps draining after an error so the lanes don't block.
+	sent := make(chan error, 1)
+	go func() {
+		var err error
+		for resp := range responses {
+			if err == nil {
+				err = stream.Send(resp)
+			}
+			done()
+		}
+		sent <- err
+	}()
+
+	err := s.readStream(stream, inflight, &pending, lanes)
+	for _, lane := range lanes {
+		close(lane)
+	}
+	wg.Wait()
+	close(responses)
+	if sendErr := <-sent; err == nil {
+		err = sendErr
+	}
+	return err
+}
+
+// readStream reads requests from the stream and hands them to their lanes
+// until the client is done sending, waiting for room in the window first (or
+// for everything to be answered, for a NOOP or a barrier).
+func (s *CacheServer) readStream(stream pb.Cache_StreamServer, inflight chan struct{}, pending *sync.WaitGroup, lanes []chan *pb.CacheRequest) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		isBarrier := barrier(in)
+		if in.Operation == pb.CacheRequest_NOOP || isBarrier {
+			pending.Wait()
+		}
+		inflight <- struct{}{}
+		pending.Add(1)
+		h := fnv.New32a()
+		h.Write([]byte(in.GetItem().GetKey()))
+		lanes[h.Sum32()%uint32(len(lanes))] <- in
+		if isBarrier {
+			pending.Wait()
+		}
+	}
+}
+
+// barrier returns true for the requests that touch more than their item's
+// key, and so can't be ordered by their lane alone.
+func barrier(in *pb.CacheRequest) bool {
+	return in.Operation == pb.CacheRequest_FLUSHALL || len(in.Keys) != 0
+}
+
+// readOnly returns true for the operations that don't change the cache, which
+// are answered even when quiet.
+func readOnly(op pb.CacheRequest_Operation) bool {
+	switch op {
+	case pb.CacheRequest_NOOP, pb.CacheRequest_GET, pb.CacheRequest_GETS, pb.CacheRequest_INSPECT, pb.CacheRequest_PEEK,
+		pb.CacheRequest_HGET, pb.CacheRequest_HGETALL, pb.CacheRequest_HLEN,
+		pb.CacheRequest_LRANGE, pb.CacheRequest_LLEN,
+		pb.CacheRequest_SISMEMBER, pb.CacheRequest_SMEMBERS, pb.CacheRequest_SCARD,
+		pb.CacheRequest_SINTER, pb.CacheRequest_SUNION, pb.CacheRequest_SDIFF,
+		pb.CacheRequest_ZRANGE, pb.CacheRequest_ZRANGEBYSCORE, pb.CacheRequest_ZRANK, pb.CacheRequest_ZCARD,
+		pb.CacheRequest_PFCOUNT, pb.CacheRequest_BFEXISTS, pb.CacheRequest_BFMEXISTS,
+		pb.CacheRequest_GETRANGE, pb.CacheRequest_STRLEN:
+		return true
+	}
+	return false
+}
+
+// returnsData returns true for the operations that change the cache but also
+// return data the client can't get any other way, like a popped value, an old
+// value or a new count, which are answered even when quiet.
+func returnsData(op pb.CacheRequest_Operation) bool {
+	switch op {
+	case pb.CacheRequest_LPOP, pb.CacheRequest_RPOP, pb.CacheRequest_GETDEL, pb.CacheRequest_GETSET, pb.CacheRequest_SETNX,
+		pb.CacheRequest_INCREMENT, pb.CacheRequest_DECREMENT, pb.CacheRequest_HINCRBY, pb.CacheRequest_ZINCRBY:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package server
+
+// waiters tracks the callers blocked waiting for a change to each key. The
+// server has one for blocking pops, which wait for a push to a list, and one
+// for Lock, which waits for a lock to be released. It's guarded by the cache
+// lock, so that checking a key and starting to wait on it can't miss a change
+// in between.
+type waiters struct {
+	waiting map[string][]chan struct{}
+	closed  chan struct{}
+}
+
+func newWaiters() *waiters {
+	return &waiters{
+		waiting: make(map[string][]chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+// wait returns a channel that is closed the next time key is woken.
+func (w *waiters) wait(key string) chan struct{} {
+	ch := make(chan struct{})
+	w.waiting[key] = append(w.waiting[key], ch)
+	return ch
+}
+
+// cancel stops waiting on ch, if it hasn't been woken already.
+func (w *waiters) cancel(key string, ch chan struct{}) {
+	waiting := w.waiting[key]
+	for i, c := range waiting {
+		if c == ch {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(w.waiting, key)
+	} else {
+		w.waiting[key] = waiting
+	}
+}
+
+// wake wakes everything waiting on key. Only as many as there are elements
+// (or one, for a lock) will get one; the rest go back to waiting.
+func (w *waiters) wake(key string) {
+	for _, ch := range w.waiting[key] {
+		close(ch)
+	}
+	delete(w.waiting, key)
+}
+
+// wakeAll wakes everything waiting on any key.
+func (w *waiters) wakeAll() {
+	for key := range w.waiting {
+		w.wake(key)
+	}
+}
+
+// close wakes every waiter for good.
+func (w *waiters) close() {
+	w.wakeAll()
+	select {
+	case <-w.closed:
+	default:
+		close(w.closed)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"strings"
+	"sync"
+
+	pb "github.com/joshrotenberg/grpc-cache/cache"
+
+	"github.com/joshrotenberg/grpc-cache/lru"
+)
+
+// defaultWatchBuffer is the number of events buffered per watcher when the
+// request doesn't ask for a specific size.
+const defaultWatchBuffer = 64
+
+// watcher is a single Watch subscription.
+type watcher struct {
+	keys     map[string]bool
+	prefixes []string
+	events   chan *pb.WatchEvent
+	// dropped counts events dropped since the last one that was queued
+	dropped uint64
+}
+
+// matches returns true if the watcher is interested in key.
+func (w *watcher) matches(key string) bool {
+	if len(w.keys) == 0 && len(w.prefixes) == 0 {
+		return true
+	}
+	if w.keys[key] {
+		return true
+	}
+	for _, prefix := range w.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchers fans cache events out to the Watch subscribers. Events are never
+// blocked on a slow subscriber: once a subscriber's buffer is full further
+// events are dropped, and the next event that makes it into the buffer
+// carries the number dropped.
+type watchers struct {
+	sync.Mutex
+	subs   map[*watcher]struct{}
+	closed bool
+}
+
+func newWatchers() *watchers {
+	return &watchers{subs: make(map[*watcher]struct{})}
+}
+
+// add registers a new watcher for the request. If the watchers have been
+// closed, the returned watcher's channel is already closed.
+func (ws *watchers) add(in *pb.WatchRequest) *watcher {
+	buffer := int(in.Buffer)
+	if buffer == 0 {
+		buffer = defaultWatchBuffer
+	}
+	w := &watcher{
+		keys:     make(map[string]bool, len(in.Keys)),
+		prefixes: in.Prefixes,
+		events:   make(chan *pb.WatchEvent, buffer),
+	}
+	for _, key := range in.Keys {
+		w.keys[key] = true
+	}
+
+	ws.Lock()
+	defer ws.Unlock()
+	if ws.closed {
+		close(w.events)
+		return w
+	}
+	ws.subs[w] = struct{}{}
+	return w
+}
+
+// remove unregisters the watcher.
+func (ws *watchers) remove(w *watcher) {
+	ws.Lock()
+	defer ws.Unlock()
+	delete(ws.subs, w)
+}
+
+// close ends all current and future subscriptions.
+func (ws *watchers) close() {
+	ws.Lock()
+	defer ws.Unlock()
+	for w := range ws.subs {
+		close(w.events)
+		delete(ws.subs, w)
+	}
+	ws.closed = true
+}
+
+// len returns the number of watchers.
+func (ws *watchers) len() int {
+	ws.Lock()
+	defer ws.Unlock()
+	return len(ws.subs)
+}
+
+// interested returns true if any watcher matches key, so callers can skip the
+// work of building an event nobody will see.
+func (ws *watchers) interested(key string) bool {
+	ws.Lock()
+	defer ws.Unlock()
+	for w := range ws.subs {
+		if w.matches(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish queues the event for every matching watcher. FLUSH events go to
+// every watcher.
+func (ws *watchers) publish(event *pb.WatchEvent) {
+	ws.Lock()
+	defer ws.Unlock()
+	for w := range ws.subs {
+		if event.Type != pb.WatchEvent_FLUSH && !w.matches(event.Key) {
+			continue
+		}
+		e := event
+		if w.dropped != 0 {
+			e = &pb.WatchEvent{Type: event.Type, Key: event.Key, Cas: event.Cas, Dropped: w.dropped}
+		}
+		select {
+		case w.events <- e:
+			w.dropped = 0
+		default:
+			w.dropped++
+		}
+	}
+}
+
+// watchedCAS returns the CAS of the item at key if anyone is watching it, or 0
+// if no one is or there's no item, so notify can tell whether an operation
+// changed it.
+func (s *CacheServer) watchedCAS(key string) uint64 {
+	if !s.watchers.interested(key) {
+		return 0
+	}
+	info, err := s.cache.Inspect(key)
+	if err != nil {
+		return 0
+	}
+	return info.CAS
+}
+
+// notify publishes the event for a successful cache operation, given the
+// item's watchedCAS from before it. Operations that succeed without changing
+// anything, like deleting a missing key or removing a member that isn't
+// there, leave the CAS as it was and aren't published. It must be called with
+// the cache lock held, from before the operation, so the reported CAS is the
+// one the operation produced.
+func (s *CacheServer) notify(op pb.CacheRequest_Operation, item *pb.CacheItem, before uint64) {
+	var eventType pb.WatchEvent_Type
+	switch op {
+	case pb.CacheRequest_SET, pb.CacheRequest_CAS, pb.CacheRequest_ADD, pb.CacheRequest_REPLACE,
+		pb.CacheRequest_APPEND, pb.CacheRequest_PREPEND, pb.CacheRequest_INCREMENT, pb.CacheRequest_DECREMENT,
+		pb.CacheRequest_HSET, pb.CacheRequest_HDEL, pb.CacheRequest_HINCRBY,
+		pb.CacheRequest_LPUSH, pb.CacheRequest_RPUSH, pb.CacheRequest_LPOP, pb.CacheRequest_RPOP, pb.CacheRequest_LTRIM,
+		pb.CacheRequest_SADD, pb.CacheRequest_SREM,
+		pb.CacheRequest_ZADD, pb.CacheRequest_ZINCRBY, pb.CacheRequest_ZREM,
+		pb.CacheRequest_PFADD, pb.CacheRequest_PFMERGE, pb.CacheRequest_BFRESERVE, pb.CacheRequest_BFADD,
+		pb.CacheRequest_GETSET, pb.CacheRequest_SETNX, pb.CacheRequest_SETRANGE:
+		eventType = pb.WatchEvent_SET
+	case pb.CacheRequest_TOUCH:
+		eventType = pb.WatchEvent_TOUCH
+	case pb.CacheRequest_DELETE, pb.CacheRequest_GETDEL:
+		eventType = pb.WatchEvent_DELETE
+	case pb.CacheRequest_FLUSHALL:
+		s.watchers.publish(&pb.WatchEvent{Type: pb.WatchEvent_FLUSH})
+		return
+	default:
+		return
+	}
+	cas := s.watchedCAS(item.Key)
+	if cas == before {
+		return
+	}
+	event := &pb.WatchEvent{Type: eventType, Key: item.Key}
+	if eventType != pb.WatchEvent_DELETE {
+		event.Cas = cas
+	}
+	s.watchers.publish(event)
+}
+
+// notifyKey publishes an event for key, looking up the item's current CAS for
+// SET and TOUCH events.
+func (s *CacheServer) notifyKey(eventType pb.WatchEvent_Type, key string) {
+	if !s.watchers.interested(key) {
+		return
+	}
+	event := &pb.WatchEvent{Type: eventType, Key: key}
+	if eventType == pb.WatchEvent_SET || eventType == pb.WatchEvent_TOUCH {
+		if info, err := s.cache.Inspect(key); err == nil {
+			event.Cas = info.CAS
+		}
+	}
+	s.watchers.publish(event)
+}
+
+// handleEviction is the cache's eviction handler, turning evictions into watch
+// events. An evicted lock is released, so anyone waiting for it is woken.
+func (s *CacheServer) handleEviction(key string, value []byte, reason lru.EvictionReason) {
+	s.lockers.wake(key)
+	eventType := pb.WatchEvent_EVICT
+	if reason == lru.TTLEviction {
+		eventType = pb.WatchEvent_EXPIRE
+	}
+	s.notifyKey(eventType, key)
+}
+
+// Watch streams events for the requested keys and prefixes until the client
+// goes away or the server is stopped. Each watcher has a bounded buffer; if
+// the client falls behind, events are dropped and the next delivered event's
+// dropped field says how many.
+func (s *CacheServer) Watch(in *pb.WatchRequest, stream pb.Cache_WatchServer) error {
+	w := s.watchers.add(in)
+	defer s.watchers.remove(w)
+	for {
+		select {
+		case event, ok := <-w.events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}